@@ -10,6 +10,7 @@ import (
 	audit_trail "github.com/scaleway/scaleway-cli/v2/internal/namespaces/audit_trail/v1alpha1"
 	autocompleteNamespace "github.com/scaleway/scaleway-cli/v2/internal/namespaces/autocomplete"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/baremetal/v1"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/batch"
 	billing "github.com/scaleway/scaleway-cli/v2/internal/namespaces/billing/v2beta1"
 	block "github.com/scaleway/scaleway-cli/v2/internal/namespaces/block/v1alpha1"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/cockpit/v1"
@@ -23,6 +24,7 @@ import (
 	flexibleip "github.com/scaleway/scaleway-cli/v2/internal/namespaces/flexibleip/v1alpha1"
 	function "github.com/scaleway/scaleway-cli/v2/internal/namespaces/function/v1beta1"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/help"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/history"
 	iam "github.com/scaleway/scaleway-cli/v2/internal/namespaces/iam/v1alpha1"
 	inference "github.com/scaleway/scaleway-cli/v2/internal/namespaces/inference/v1beta1"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/info"
@@ -108,6 +110,8 @@ func GetCommands() *core.Commands {
 		login.GetCommands(),
 		mongodb.GetCommands(),
 		audit_trail.GetCommands(),
+		batch.GetCommands(),
+		history.GetCommands(),
 	)
 
 	if beta {
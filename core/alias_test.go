@@ -1,6 +1,11 @@
 package core_test
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/alecthomas/assert"
@@ -73,3 +78,61 @@ func TestAliasChildCommand(t *testing.T) {
 
 	assert.False(t, commands.AliasIsValidCommandChild(namespace, invalidAlias))
 }
+
+type greetArgs struct {
+	Name     string
+	Greeting string
+}
+
+func Test_AliasPlaceholders(t *testing.T) {
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "greet",
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(greetArgs{}),
+			ArgSpecs: core.ArgSpecs{
+				{Name: "name"},
+				{Name: "greeting"},
+			},
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				args := argsI.(*greetArgs)
+				return fmt.Sprintf("%s %s", args.Greeting, args.Name), nil
+			},
+		},
+	)
+
+	writeAliases := func(ctx *core.BeforeFuncCtx) error {
+		configDir := filepath.Join(ctx.OverrideEnv["HOME"], ".config", "scw")
+		err := os.MkdirAll(configDir, 0o700)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(configDir, "cli.yaml"), []byte(`alias:
+  aliases:
+    hi:
+      - test
+      - greet
+      - name=$1
+      - greeting=${greeting:-hello}
+`), 0o600)
+	}
+
+	t.Run("default", core.Test(&core.TestConfig{
+		Commands:      commands,
+		TmpHomeDir:    true,
+		EnableAliases: true,
+		BeforeFunc:    writeAliases,
+		Cmd:           "scw hi bob",
+		Check:         core.TestCheckGolden(),
+	}))
+
+	t.Run("named", core.Test(&core.TestConfig{
+		Commands:      commands,
+		TmpHomeDir:    true,
+		EnableAliases: true,
+		BeforeFunc:    writeAliases,
+		Cmd:           "scw hi greeting=bonjour bob",
+		Check:         core.TestCheckGolden(),
+	}))
+}
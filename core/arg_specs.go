@@ -105,12 +105,36 @@ type ArgSpec struct {
 
 	// CanLoadFile allow to use @ prefix to load a file as content
 	CanLoadFile bool
+
+	// Secret marks arguments whose value is sensitive, e.g. the data of a secret version.
+	// Their value is never written to the history. It is inferred for the names of secretArgNames.
+	Secret bool
 }
 
+// secretArgNames are the names of secret args, e.g. password for users.{index}.password.
+// Names ending with -<name> are secret too, e.g. root-password.
+var secretArgNames = []string{"password", "secret-key", "token", "passphrase", "private-key"}
+
 func (a *ArgSpec) Prefix() string {
 	return a.Name + "="
 }
 
+// IsSecret returns true if the value of the argument is sensitive, see Secret.
+// Values of secret lists or maps are secret too, e.g. secret-environment-variables.{index}.value.
+func (a *ArgSpec) IsSecret() bool {
+	if a.Secret {
+		return true
+	}
+	parts := strings.Split(a.Name, ".")
+	last := parts[len(parts)-1]
+	for _, name := range secretArgNames {
+		if last == name || strings.HasSuffix(last, "-"+name) {
+			return true
+		}
+	}
+	return len(parts) > 1 && strings.HasPrefix(parts[0], "secret-") && last == "value"
+}
+
 func (a *ArgSpec) IsPartOfMapOrSlice() bool {
 	return strings.Contains(a.Name, sliceSchema) || strings.Contains(a.Name, mapSchema)
 }
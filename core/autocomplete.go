@@ -230,6 +230,16 @@ func BuildAutoCompleteTree(ctx context.Context, commands *Commands) *AutoComplet
 func AutoComplete(ctx context.Context, leftWords []string, wordToComplete string, rightWords []string) *AutocompleteResponse {
 	commands := ExtractCommands(ctx)
 
+	// Namespaces of plugins are completed with the plugins found in PATH.
+	// Commands of plugins are listed in their manifest, which is loaded when a plugin namespace is completed.
+	switch {
+	case len(leftWords) == 1:
+		registerPlugins(ctx, commands, false)
+	case len(leftWords) > 1:
+		registerPlugin(ctx, commands, leftWords[1])
+		loadPluginCommands(ctx, commands, leftWords[1])
+	}
+
 	// Create AutoComplete Tree
 	commandTreeRoot := BuildAutoCompleteTree(ctx, commands)
 
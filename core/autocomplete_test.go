@@ -172,10 +172,10 @@ func TestAutocomplete(t *testing.T) {
 	t.Run("scw test flower delete hibiscus with-leaves=tr", run(&testCase{Suggestions: core.AutocompleteSuggestions{"with-leaves=true"}}))
 	t.Run("scw test flower delete hibiscus with-leaves=yes", run(&testCase{Suggestions: nil}))
 	t.Run("scw test flower create leaves.0.size=", run(&testCase{Suggestions: core.AutocompleteSuggestions{"leaves.0.size=L", "leaves.0.size=M", "leaves.0.size=S", "leaves.0.size=XL", "leaves.0.size=XXL"}}))
-	t.Run("scw -", run(&testCase{Suggestions: core.AutocompleteSuggestions{"--config", "--debug", "--help", "--output", "--profile", "-D", "-c", "-h", "-o", "-p"}}))
+	t.Run("scw -", run(&testCase{Suggestions: core.AutocompleteSuggestions{"--config", "--debug", "--dry-run", "--filter", "--help", "--output", "--parallel", "--profile", "--query", "--sort-by", "--timeout", "--wait-interval", "--yes", "-D", "-c", "-h", "-o", "-p", "-y"}}))
 	t.Run("scw test -o j", run(&testCase{Suggestions: core.AutocompleteSuggestions{"json"}}))
-	t.Run("scw test flower -o ", run(&testCase{Suggestions: core.AutocompleteSuggestions{core.PrinterTypeHuman.String(), core.PrinterTypeJSON.String(), core.PrinterTypeTemplate.String(), core.PrinterTypeYAML.String()}}))
-	t.Run("scw test flower -o json create -", run(&testCase{Suggestions: core.AutocompleteSuggestions{"--config", "--debug", "--help", "--output", "--profile", "--wait", "-D", "-c", "-h", "-p", "-w"}}))
+	t.Run("scw test flower -o ", run(&testCase{Suggestions: core.AutocompleteSuggestions{core.PrinterTypeCSV.String(), core.PrinterTypeHTML.String(), core.PrinterTypeHuman.String(), core.PrinterTypeJSON.String(), core.PrinterTypeMarkdown.String(), core.PrinterTypeNDJSON.String(), core.PrinterTypeTemplate.String(), core.PrinterTypeTSV.String(), core.PrinterTypeYAML.String()}}))
+	t.Run("scw test flower -o json create -", run(&testCase{Suggestions: core.AutocompleteSuggestions{"--config", "--debug", "--dry-run", "--filter", "--help", "--output", "--parallel", "--profile", "--query", "--sort-by", "--timeout", "--wait", "--wait-interval", "--yes", "-D", "-c", "-h", "-p", "-w", "-y"}}))
 	t.Run("scw test flower create name=p -o j", run(&testCase{Suggestions: core.AutocompleteSuggestions{"json"}}))
 	t.Run("scw test flower create name=p -o json ", run(&testCase{Suggestions: core.AutocompleteSuggestions{"colours.0=", "leaves.", "size=", "species="}}))
 	t.Run("scw test flower create name=p -o=json ", run(&testCase{Suggestions: core.AutocompleteSuggestions{"colours.0=", "leaves.", "size=", "species="}}))
@@ -186,8 +186,8 @@ func TestAutocomplete(t *testing.T) {
 	t.Run("scw test --profile xxxx flower create name=p ", run(&testCase{Suggestions: core.AutocompleteSuggestions{"colours.0=", "leaves.", "size=", "species="}}))
 	t.Run("scw test flower create name=p --profile xxxx", run(&testCase{Suggestions: nil}))
 
-	t.Run("scw test flower -o json delete -", run(&testCase{Suggestions: core.AutocompleteSuggestions{"--config", "--debug", "--help", "--output", "--profile", "-D", "-c", "-h", "-p"}}))
-	t.Run("scw test flower delete -o ", run(&testCase{Suggestions: core.AutocompleteSuggestions{core.PrinterTypeHuman.String(), core.PrinterTypeJSON.String(), core.PrinterTypeTemplate.String(), core.PrinterTypeYAML.String()}}))
+	t.Run("scw test flower -o json delete -", run(&testCase{Suggestions: core.AutocompleteSuggestions{"--config", "--debug", "--dry-run", "--filter", "--help", "--output", "--parallel", "--profile", "--query", "--sort-by", "--timeout", "--wait-interval", "--yes", "-D", "-c", "-h", "-p", "-y"}}))
+	t.Run("scw test flower delete -o ", run(&testCase{Suggestions: core.AutocompleteSuggestions{core.PrinterTypeCSV.String(), core.PrinterTypeHTML.String(), core.PrinterTypeHuman.String(), core.PrinterTypeJSON.String(), core.PrinterTypeMarkdown.String(), core.PrinterTypeNDJSON.String(), core.PrinterTypeTemplate.String(), core.PrinterTypeTSV.String(), core.PrinterTypeYAML.String()}}))
 	t.Run("scw test flower delete -o j", run(&testCase{Suggestions: core.AutocompleteSuggestions{"json"}}))
 	t.Run("scw test flower delete -o json ", run(&testCase{Suggestions: core.AutocompleteSuggestions{"anemone", "hibiscus", "with-leaves="}}))
 	t.Run("scw test flower delete -o=json ", run(&testCase{Suggestions: core.AutocompleteSuggestions{"anemone", "hibiscus", "with-leaves="}}))
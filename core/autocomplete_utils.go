@@ -12,8 +12,6 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/strcase"
 )
 
-var autoCompleteCache *cache.Cache
-
 // getGlobalFlags returns the list of flags that should be added to all commands
 func getGlobalFlags(ctx context.Context) []FlagSpec {
 	printerTypes := []string{
@@ -21,6 +19,11 @@ func getGlobalFlags(ctx context.Context) []FlagSpec {
 		PrinterTypeJSON.String(),
 		PrinterTypeYAML.String(),
 		PrinterTypeTemplate.String(),
+		PrinterTypeCSV.String(),
+		PrinterTypeTSV.String(),
+		PrinterTypeNDJSON.String(),
+		PrinterTypeMarkdown.String(),
+		PrinterTypeHTML.String(),
 	}
 	profiles := []string(nil)
 	cfg := extractConfig(ctx)
@@ -35,6 +38,11 @@ func getGlobalFlags(ctx context.Context) []FlagSpec {
 		{Name: "--config"},
 		{Name: "-D"},
 		{Name: "--debug"},
+		{Name: "--dry-run"},
+		{
+			Name:             "--filter",
+			HasVariableValue: true,
+		},
 		{Name: "-h"},
 		{Name: "--help"},
 		{
@@ -45,6 +53,14 @@ func getGlobalFlags(ctx context.Context) []FlagSpec {
 			Name:       "--output",
 			EnumValues: printerTypes,
 		},
+		{
+			Name:             "--parallel",
+			HasVariableValue: true,
+		},
+		{
+			Name:             "--query",
+			HasVariableValue: true,
+		},
 		{
 			Name:             "-p",
 			HasVariableValue: true,
@@ -55,6 +71,20 @@ func getGlobalFlags(ctx context.Context) []FlagSpec {
 			HasVariableValue: true,
 			EnumValues:       profiles,
 		},
+		{
+			Name:             "--sort-by",
+			HasVariableValue: true,
+		},
+		{
+			Name:             "--timeout",
+			HasVariableValue: true,
+		},
+		{
+			Name:             "--wait-interval",
+			HasVariableValue: true,
+		},
+		{Name: "-y"},
+		{Name: "--yes"},
 	}
 }
 
@@ -152,14 +182,17 @@ func AutocompleteGetArg(ctx context.Context, cmd *Command, argSpec *ArgSpec, com
 		}
 	}
 
-	rawCommand := fmt.Sprintf("%s %s", listCmd.getPath(), strings.Join(listRawArgs, " "))
-	resp := autoCompleteCache.Get(rawCommand)
-	if resp == nil {
-		resp, err = listCmd.Interceptor(ctx, listCmdArgs, listCmd.Run)
-		if err != nil {
-			return nil
-		}
-		autoCompleteCache.Set(rawCommand, resp)
+	// Values are cached by profile as listed resources depend on the credentials.
+	responseCache := ExtractCache(ctx)
+	cacheKey := fmt.Sprintf("%s %s %s %s", ExtractProfileName(ctx), listCmd.getPath(), strings.Join(listRawArgs, " "), argName)
+	values := []string(nil)
+	if responseCache.Get(listCmd.Namespace, cacheKey, &values) {
+		return values
+	}
+
+	resp, err := listCmd.Interceptor(ctx, listCmdArgs, listCmd.Run)
+	if err != nil {
+		return nil
 	}
 
 	// As we run the "list" verb instead of using the sdk ListResource, response is already the slice
@@ -168,7 +201,6 @@ func AutocompleteGetArg(ctx context.Context, cmd *Command, argSpec *ArgSpec, com
 	if resources.Kind() != reflect.Slice {
 		return nil
 	}
-	values := []string(nil)
 	// Let's iterate over the struct in the response slice and get the searched field
 	for i := range resources.Len() {
 		resource := resources.Index(i)
@@ -181,6 +213,8 @@ func AutocompleteGetArg(ctx context.Context, cmd *Command, argSpec *ArgSpec, com
 		}
 	}
 
+	responseCache.Set(listCmd.Namespace, cacheKey, values, cache.DefaultTTL)
+
 	return values
 }
 
@@ -2,15 +2,21 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/account"
+	"github.com/scaleway/scaleway-cli/v2/internal/cache"
 	cliConfig "github.com/scaleway/scaleway-cli/v2/internal/config"
 	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
 	"github.com/scaleway/scaleway-cli/v2/internal/platform"
+	"github.com/scaleway/scaleway-cli/v2/internal/query"
 	"github.com/scaleway/scaleway-sdk-go/logger"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/spf13/cobra"
@@ -47,6 +53,10 @@ type BootstrapConfig struct {
 	// DisableAliases, if set to true this will disable aliases expanding
 	DisableAliases bool
 
+	// DisableHistory, if set to true the command is not recorded in the history.
+	// This is useful when running test to avoid filling the history of the user.
+	DisableHistory bool
+
 	// OverrideEnv overrides environment variables returned by core.ExtractEnv function.
 	// This is useful for tests as it allows overriding env without relying on global state.
 	OverrideEnv map[string]string
@@ -63,7 +73,7 @@ type BootstrapConfig struct {
 	// Optional we use it if defined
 	Logger *Logger
 
-	// Default HTTPClient to use. If not provided it will use a basic http client with a simple retry policy
+	// Default HTTPClient to use. If not provided it will use a basic http client retrying transient errors, see the retry section of the CLI config
 	// This client will be used to create SDK client, account call, version checking and telemetry
 	HTTPClient *http.Client
 
@@ -72,6 +82,10 @@ type BootstrapConfig struct {
 
 	// The current platform, should probably be platform.Default
 	Platform platform.Platform
+
+	// WorkDir is the directory from which the project config file is looked for, see cliConfig.FindProjectConfig.
+	// The current directory is used if it is empty.
+	WorkDir string
 }
 
 // Bootstrap is the main entry point. It is directly called from main.
@@ -83,11 +97,27 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 	var profileFlag string
 	var configPathFlag string
 	var outputFlag string
+	var queryFlag string
+	var filterFlag string
+	var sortByFlag string
+	var dryRun bool
+	var parallelFlag int
+	var yes bool
+	var timeoutFlag time.Duration
+	var waitIntervalFlag time.Duration
 
 	flags := pflag.NewFlagSet(config.Args[0], pflag.ContinueOnError)
 	flags.StringVarP(&profileFlag, "profile", "p", "", "The config profile to use")
 	flags.StringVarP(&configPathFlag, "config", "c", "", "The path to the config file")
 	flags.StringVarP(&outputFlag, "output", "o", cliConfig.DefaultOutput, "Output format: json or human")
+	flags.StringVar(&queryFlag, "query", "", "JMESPath query to filter the result")
+	flags.StringVar(&filterFlag, "filter", "", "Filter list results")
+	flags.StringVar(&sortByFlag, "sort-by", "", "Sort list results")
+	flags.BoolVar(&dryRun, "dry-run", false, "Print the API calls that modify resources instead of sending them")
+	flags.IntVar(&parallelFlag, "parallel", 1, "Number of positional arguments processed concurrently")
+	flags.BoolVarP(&yes, "yes", "y", false, "Run destructive commands without confirmation")
+	flags.DurationVar(&timeoutFlag, "timeout", 0, "Maximum duration of the waits of the command")
+	flags.DurationVar(&waitIntervalFlag, "wait-interval", 0, "Interval between two checks of a resource state while waiting")
 	flags.BoolVarP(&debug, "debug", "D", os.Getenv("SCW_DEBUG") == "true", "Enable debug mode")
 	// Ignore unknown flag
 	flags.ParseErrorsWhitelist.UnknownFlags = true
@@ -126,9 +156,10 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 
 	// The printer must be the first thing set in order to print errors
 	printer, err := NewPrinter(&PrinterConfig{
-		OutputFlag: outputFlag,
-		Stdout:     config.Stdout,
-		Stderr:     config.Stderr,
+		OutputFlag:  outputFlag,
+		Stdout:      config.Stdout,
+		Stderr:      config.Stderr,
+		OverrideEnv: config.OverrideEnv,
 	})
 	if err != nil {
 		_, _ = fmt.Fprintln(config.Stderr, err)
@@ -136,26 +167,97 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 	}
 	interactive.SetOutputWriter(config.Stderr) // set printer for interactive function (always stderr).
 
+	// The query is compiled before running the command to avoid running it if the query is invalid.
+	resultQuery, err := compileQuery(queryFlag)
+	if err != nil {
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(config.Stderr, printErr)
+		}
+		return 1, nil, err
+	}
+
+	resultListOptions, err := parseResultListOptions(filterFlag, sortByFlag)
+	if err != nil {
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(config.Stderr, printErr)
+		}
+		return 1, nil, err
+	}
+
+	if parallelFlag < 1 {
+		err := &CliError{
+			Err:  fmt.Errorf("invalid value '%d' for --parallel", parallelFlag),
+			Hint: "--parallel must be at least 1",
+		}
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(config.Stderr, printErr)
+		}
+		return 1, nil, err
+	}
+
+	if timeoutFlag < 0 || waitIntervalFlag < 0 {
+		err := &CliError{
+			Err:  errors.New("invalid negative duration for --timeout or --wait-interval"),
+			Hint: "Durations must be positive, e.g. 30m or 10s",
+		}
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(config.Stderr, printErr)
+		}
+		return 1, nil, err
+	}
+
+	// The retry policy is set once the CLI config is loaded.
+	var retryTransport *retryableHTTPTransport
 	httpClient := config.HTTPClient
 	if httpClient == nil {
+		retryTransport = &retryableHTTPTransport{
+			transport: &SocketPassthroughTransport{},
+			logger:    log,
+		}
 		httpClient = &http.Client{
-			Transport: &retryableHTTPTransport{transport: &SocketPassthroughTransport{}},
+			Transport: retryTransport,
 		}
 	}
 
+	// In dry-run mode, requests modifying resources are printed instead of being sent.
+	if dryRun {
+		httpClient = &http.Client{
+			Transport:     newDryRunTransport(httpClient.Transport, config.Stderr),
+			CheckRedirect: httpClient.CheckRedirect,
+			Jar:           httpClient.Jar,
+			Timeout:       httpClient.Timeout,
+		}
+	}
+
+	// List pages go through this transport to be printed as soon as they are fetched.
+	listStreaming := newListStreamingTransport(httpClient.Transport)
+	httpClient = &http.Client{
+		Transport:     listStreaming,
+		CheckRedirect: httpClient.CheckRedirect,
+		Jar:           httpClient.Jar,
+		Timeout:       httpClient.Timeout,
+	}
+
 	// An authenticated client will be created later if required.
 	client := config.Client
 	isClientFromBootstrapConfig := true
-	if client == nil {
+	switch {
+	case client == nil:
 		isClientFromBootstrapConfig = false
 		client, err = createAnonymousClient(httpClient, config.BuildInfo)
-		if err != nil {
-			printErr := printer.Print(err, nil)
-			if printErr != nil {
-				_, _ = fmt.Fprintln(config.Stderr, printErr)
-			}
-			return 1, nil, err
+	case dryRun:
+		client, err = copyClient(client, httpClient, config.BuildInfo)
+	}
+	if err != nil {
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(config.Stderr, printErr)
 		}
+		return 1, nil, err
 	}
 
 	// Meta store globally available variables like SDK client.
@@ -176,8 +278,17 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 		stderr:                      config.Stderr,
 		stdin:                       config.Stdin,
 		result:                      nil, // result is later injected by cobra_utils.go/cobraRun()
+		resultListOptions:           resultListOptions,
+		listStreaming:               listStreaming,
+		dryRun:                      dryRun,
+		yes:                         yes,
+		parallel:                    parallelFlag,
+		timeout:                     timeoutFlag,
+		waitInterval:                waitIntervalFlag,
+		disableHistory:              config.DisableHistory,
 		command:                     nil, // command is later injected by cobra_utils.go/cobraRun()
 		httpClient:                  httpClient,
+		secretKeys:                  &sync.Map{},
 		isClientFromBootstrapConfig: isClientFromBootstrapConfig,
 		BetaMode:                    config.BetaMode,
 	}
@@ -197,6 +308,7 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 	}
 	ctx = account.InjectHTTPClient(ctx, httpClient)
 	ctx = InjectMeta(ctx, meta)
+	meta.cache = cache.New(filepath.Join(ExtractCacheDir(ctx), responseCacheDirName))
 
 	// Load CLI config
 	cliCfg, err := cliConfig.LoadConfig(ExtractCliConfigPath(ctx))
@@ -208,12 +320,77 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 		return 1, nil, err
 	}
 	meta.CliConfig = cliCfg
+
+	// The project config file is looked for from the working directory, e.g. in a repository.
+	meta.workDir = config.WorkDir
+	if meta.workDir == "" {
+		meta.workDir, _ = os.Getwd()
+	}
+	if meta.workDir != "" {
+		meta.projectConfig, err = cliConfig.FindProjectConfig(meta.workDir)
+		if err != nil {
+			err = &CliError{
+				Err:  err,
+				Hint: "Fix or remove the project config file, it can set profile, default_project_id, default_region, default_zone, output and aliases",
+			}
+			printErr := printer.Print(err, nil)
+			if printErr != nil {
+				_, _ = fmt.Fprintln(config.Stderr, printErr)
+			}
+			return 1, nil, err
+		}
+	}
+	meta.aliases, err = meta.projectConfig.MergeAliases(cliCfg.Alias, config.Commands.words())
+	if err != nil {
+		err = &CliError{
+			Err:  err,
+			Hint: "Rename the alias in the project config file, aliases of project config files cannot replace commands or aliases of the CLI config",
+		}
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(config.Stderr, printErr)
+		}
+		return 1, nil, err
+	}
+
+	preferences, err := humanPreferences(cliCfg.Human)
+	if err != nil {
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(config.Stderr, printErr)
+		}
+		return 1, nil, err
+	}
+	meta.humanPreferences = preferences
+	defer applyHumanPreferences(ctx)()
+
+	policy, err := loadRetryPolicy(ctx, cliCfg.Retry)
+	if err != nil {
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(config.Stderr, printErr)
+		}
+		return 1, nil, err
+	}
+	if retryTransport != nil {
+		retryTransport.policy = policy
+	}
+
+	output := outputFlag
 	if cliCfg.Output != cliConfig.DefaultOutput {
-		outputFlag = cliCfg.Output
+		output = cliCfg.Output
+	}
+	// The output of the project config file has priority over the CLI config but not over the --output flag.
+	if meta.projectConfig != nil && meta.projectConfig.Output != "" && !flags.Changed("output") {
+		output = meta.projectConfig.Output
+	}
+	if output != outputFlag {
+		outputFlag = output
 		printer, err = NewPrinter(&PrinterConfig{
-			OutputFlag: outputFlag,
-			Stdout:     config.Stdout,
-			Stderr:     config.Stderr,
+			OutputFlag:  outputFlag,
+			Stdout:      config.Stdout,
+			Stderr:      config.Stderr,
+			OverrideEnv: config.OverrideEnv,
 		})
 		if err != nil {
 			_, _ = fmt.Fprintln(config.Stderr, err)
@@ -227,8 +404,21 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 		runAfterCommandChecks(ctx, config.BuildInfo.checkVersion, checkAPIKey)
 	}()
 
+	// Plugins are registered before aliases so that aliases can target them.
+	// Their manifests describe their namespace in the root usage, other commands run plugins without them.
+	// PATH is only scanned for the root usage, otherwise the plugin of the namespace is looked for if it is unknown.
+	if isRootUsage(config.Args[1:]) {
+		registerPlugins(ctx, config.Commands, true)
+	} else if i := namespaceIndex(config.Args[1:], flags); i >= 0 {
+		namespace := config.Args[1+i]
+		if alias := meta.aliases.GetAlias(namespace); len(alias) > 0 && !config.DisableAliases {
+			namespace = alias[0]
+		}
+		registerPlugin(ctx, config.Commands, namespace)
+	}
+
 	if !config.DisableAliases {
-		config.Commands.applyAliases(meta.CliConfig.Alias)
+		config.Commands.applyAliases(meta.aliases)
 	}
 
 	// cobraBuilder will build a Cobra root command from a list of Command
@@ -249,7 +439,28 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 	args := config.Args[1:]
 	// Do not resolve aliases if using a disabled namespace
 	if (len(config.Args) < 2 || !aliasDisabled(config.Args[1])) && !config.DisableAliases {
-		args = meta.CliConfig.Alias.ResolveAliases(args)
+		args = meta.aliases.ResolveAliases(args)
+	}
+
+	defer func() {
+		recordHistory(ctx, args, exitCode, result)
+	}()
+
+	// Namespaces provided by plugins are run by their executable, which handles its own args and usage.
+	if pluginCmd, pluginArgs := findPluginCommand(config.Commands, args, flags); pluginCmd != nil {
+		exitCode := 1
+		// Plugins get credentials and their requests cannot be intercepted.
+		err := dryRunUnsupportedError(ctx, pluginCmd)
+		if err == nil {
+			exitCode, err = runPlugin(ctx, pluginCmd, pluginArgs)
+		}
+		if err != nil {
+			printErr := printer.Print(err, nil)
+			if printErr != nil {
+				_, _ = fmt.Fprintln(config.Stderr, printErr)
+			}
+		}
+		return exitCode, nil, err
 	}
 
 	// These flag are already handle at the beginning of this function but we keep this
@@ -257,7 +468,20 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 	rootCmd.PersistentFlags().StringVarP(&profileFlag, "profile", "p", "", "The config profile to use")
 	rootCmd.PersistentFlags().StringVarP(&configPathFlag, "config", "c", "", "The path to the config file")
 	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", cliConfig.DefaultOutput, "Output format: json or human, see 'scw help output' for more info")
+	rootCmd.PersistentFlags().StringVar(&queryFlag, "query", "", "JMESPath query to filter the result, see 'scw help output' for more info")
+	rootCmd.PersistentFlags().StringVar(&filterFlag, "filter", "", "Filter list results, e.g. state=running,tags~prod, see 'scw help output' for more info")
+	rootCmd.PersistentFlags().StringVar(&sortByFlag, "sort-by", "", "Sort list results by fields, e.g. -creation_date, see 'scw help output' for more info")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the API calls that modify resources instead of sending them")
+	rootCmd.PersistentFlags().IntVar(&parallelFlag, "parallel", 1, "Number of positional arguments processed concurrently, e.g. to delete several servers at once")
+	rootCmd.PersistentFlags().BoolVarP(&yes, "yes", "y", false, "Run destructive commands, e.g. deletions, without asking for a confirmation")
+	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 0, "Maximum duration of the waits of the command, e.g. 30m. The command exits with code 124 once it is reached, API requests in progress are not interrupted")
+	rootCmd.PersistentFlags().DurationVar(&waitIntervalFlag, "wait-interval", 0, "Interval between two checks of the resource state with --wait, e.g. 10s")
 	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "D", false, "Enable debug mode")
+	// Streamed results cannot be post-processed.
+	if printer.streamsLists() && resultQuery == nil && resultListOptions == nil {
+		listStreaming.printer = printer
+	}
+
 	rootCmd.SetArgs(args)
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true})
 	err = rootCmd.Execute()
@@ -269,19 +493,106 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 		if cliErr, ok := err.(*CliError); ok && cliErr.Code != 0 {
 			errorCode = cliErr.Code
 		}
+		// Commands run in parallel for several positional arguments keep the results of the runs that succeeded.
+		if meta.command != nil && meta.result != nil {
+			meta.result, _ = printResult(printer, meta, resultQuery)
+		}
 		printErr := printer.Print(err, nil)
 		if printErr != nil {
 			_, _ = fmt.Fprintln(os.Stderr, err)
 		}
-		return errorCode, nil, err
+		return errorCode, meta.result, err
+	}
+
+	// The result was already printed page by page.
+	if listStreaming.isStreamed() {
+		return 0, meta.result, nil
 	}
 
 	if meta.command != nil {
-		printErr := printer.Print(meta.result, meta.command.getHumanMarshalerOpt())
-		if printErr != nil {
-			_, _ = fmt.Fprintln(config.Stderr, printErr)
+		meta.result, err = printResult(printer, meta, resultQuery)
+		if err != nil {
+			printErr := printer.Print(err, nil)
+			if printErr != nil {
+				_, _ = fmt.Fprintln(config.Stderr, printErr)
+			}
+			return 1, nil, err
 		}
 	}
 
 	return 0, meta.result, nil
 }
+
+// printResult prints the result of the command, filtered by the --query flag if any, and returns the printed result.
+// Errors of the printer, e.g. an unknown column, are returned so that the command exits with an error.
+func printResult(printer *Printer, meta *Meta, resultQuery *query.Query) (interface{}, error) {
+	result := meta.result
+	humanMarshalerOpt := meta.command.getHumanMarshalerOpt()
+	if resultQuery != nil {
+		var err error
+		result, err = searchResult(resultQuery, result)
+		if err != nil {
+			return nil, err
+		}
+		// The command view describes the original result, not the queried one.
+		humanMarshalerOpt = nil
+	}
+
+	err := printer.Print(result, humanMarshalerOpt)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BootstrapConfigFromContext returns a config to bootstrap a command in-process from a running command, e.g. to chain commands.
+// args must not contain the binary name. The command shares the streams and the global flags of the running command.
+// The client is shared only if it was given to the running command, otherwise the command creates its own for its profile.
+func BootstrapConfigFromContext(ctx context.Context, args []string) *BootstrapConfig {
+	meta := extractMeta(ctx)
+
+	var client *scw.Client
+	if meta.isClientFromBootstrapConfig {
+		client = meta.Client
+	}
+
+	args = append([]string{meta.BinaryName}, args...)
+	if meta.ProfileFlag != "" {
+		args = append(args, "--profile", meta.ProfileFlag)
+	}
+	if meta.ConfigPathFlag != "" {
+		args = append(args, "--config", meta.ConfigPathFlag)
+	}
+	if meta.dryRun {
+		args = append(args, "--dry-run")
+	}
+	if meta.yes {
+		args = append(args, "--yes")
+	}
+	// The deadline of --timeout is carried by the context.
+	if meta.waitInterval > 0 {
+		args = append(args, "--wait-interval", meta.waitInterval.String())
+	}
+	if meta.Logger != nil && meta.Logger.ShouldLog(logger.LogLevelDebug) {
+		args = append(args, "--debug")
+	}
+
+	return &BootstrapConfig{
+		Args:           args,
+		Commands:       meta.Commands,
+		BuildInfo:      meta.BuildInfo,
+		Stdout:         meta.stdout,
+		Stderr:         meta.stderr,
+		Stdin:          meta.stdin,
+		Client:         client,
+		OverrideEnv:    meta.OverrideEnv,
+		OverrideExec:   meta.OverrideExec,
+		Ctx:            ctx,
+		Logger:         meta.Logger,
+		HTTPClient:     meta.httpClient,
+		BetaMode:       meta.BetaMode,
+		Platform:       meta.Platform,
+		DisableHistory: meta.disableHistory,
+		WorkDir:        meta.workDir,
+	}
+}
@@ -79,6 +79,10 @@ func runAfterCommandChecks(ctx context.Context, checkFuncs ...AfterCommandCheckF
 
 // Check if API Key is about to expire
 func checkAPIKey(ctx context.Context) {
+	// The secret_key_command of the profile is not run only to check its key.
+	if extractMeta(ctx).lazyClient.isPending() {
+		return
+	}
 	client := ExtractClient(ctx)
 	if client == nil {
 		return
@@ -1,10 +1,13 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/platform"
+	"github.com/scaleway/scaleway-cli/v2/internal/profiles"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
@@ -24,6 +27,83 @@ func createAnonymousClient(httpClient *http.Client, buildInfo *BuildInfo) (*scw.
 	return client, nil
 }
 
+// copyClient returns a copy of a client provided in the bootstrap config sending its requests through httpClient,
+// e.g. through the dry-run and list streaming transports.
+func copyClient(client *scw.Client, httpClient *http.Client, buildInfo *BuildInfo) (*scw.Client, error) {
+	opts := []scw.ClientOption{
+		scw.WithUserAgent(buildInfo.GetUserAgent()),
+		scw.WithHTTPClient(httpClient),
+	}
+	if accessKey, exists := client.GetAccessKey(); exists {
+		secretKey, _ := client.GetSecretKey()
+		opts = append(opts, scw.WithAuth(accessKey, secretKey))
+	}
+	if organizationID, exists := client.GetDefaultOrganizationID(); exists {
+		opts = append(opts, scw.WithDefaultOrganizationID(organizationID))
+	}
+	if projectID, exists := client.GetDefaultProjectID(); exists {
+		opts = append(opts, scw.WithDefaultProjectID(projectID))
+	}
+	if region, exists := client.GetDefaultRegion(); exists {
+		opts = append(opts, scw.WithDefaultRegion(region))
+	}
+	if zone, exists := client.GetDefaultZone(); exists {
+		opts = append(opts, scw.WithDefaultZone(zone))
+	}
+	if pageSize, exists := client.GetDefaultPageSize(); exists {
+		opts = append(opts, scw.WithDefaultPageSize(pageSize))
+	}
+	return scw.NewClient(opts...)
+}
+
+// createClient creates the client of the active profile, running its secret_key_command if any.
+// The values of the project config file have priority over the ones of the profile.
+func createClient(ctx context.Context) (*scw.Client, error) {
+	meta := extractMeta(ctx)
+	secretKey := func(profileName string, extension *profiles.Extension) (string, error) {
+		return SecretKeyFromCommand(ctx, profileName, extension)
+	}
+	return meta.Platform.CreateClient(meta.httpClient, ExtractConfigPath(ctx), ExtractProfileName(ctx), secretKey, ExtractProjectConfig(ctx).ScwProfile())
+}
+
+// lazyClient creates the client of the profile the first time the command uses it, see ExtractClient.
+// The secret_key_command of the profile, which may ask for a passphrase, does not run for commands that send no
+// request, e.g. completions. The client is created once, even by commands run for several positional arguments.
+type lazyClient struct {
+	once sync.Once
+	done bool
+	err  error
+}
+
+// create sets the client of the profile in the metadata, the anonymous client is kept if it cannot be created.
+func (c *lazyClient) create(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	c.once.Do(func() {
+		c.done = true
+		client, err := createClient(ctx)
+		if err != nil {
+			c.err = err
+			return
+		}
+		extractMeta(ctx).Client = client
+	})
+}
+
+// isPending returns true if the client of the profile was not created yet because the command did not use it.
+func (c *lazyClient) isPending() bool {
+	return c != nil && !c.done
+}
+
+// error returns the error of the creation of the client, if it was created.
+func (c *lazyClient) error() error {
+	if c == nil {
+		return nil
+	}
+	return c.err
+}
+
 func createClientError(err error) error {
 	credentialsHint := "You can get your credentials here: https://console.scaleway.com/iam/api-keys"
 
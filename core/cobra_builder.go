@@ -128,6 +128,10 @@ func (b *cobraBuilder) hydrateCobra(cobraCmd *cobra.Command, cmd *Command, group
 			cobraCmd.Annotations["UsageDeprecatedArgs"] = BuildUsageArgs(b.ctx, cmd, true)
 		}
 
+		if defaults := ConfigDefaults(b.ctx, cmd); cmd.Run != nil && len(defaults) > 0 {
+			cobraCmd.Annotations["ConfigDefaults"] = "  " + strings.Join(defaults, "\n  ")
+		}
+
 		if cmd.Examples != nil {
 			cobraCmd.Annotations["Examples"] = buildExamples(b.meta.BinaryName, cmd)
 		}
@@ -203,6 +207,11 @@ ARGS:
 DEPRECATED ARGS:
 {{.Annotations.UsageDeprecatedArgs}}
 {{- end}}
+{{- if .Annotations.ConfigDefaults}}
+
+DEFAULTS FROM THE CLI CONFIG:
+{{.Annotations.ConfigDefaults}}
+{{- end}}
 {{- if .HasAvailableSubCommands}}
 
 {{- range $_, $group := orderGroups (getCommandsGroups .Commands) }}
@@ -19,16 +19,22 @@ func cobraRun(ctx context.Context, cmd *Command) func(*cobra.Command, []string)
 
 		meta := extractMeta(ctx)
 		meta.command = cmd
+		meta.secretArgValues = secretArgValues(cmd.ArgSpecs, rawArgs)
 
 		sentry.AddCommandContext(cmd.GetCommandLine("scw"))
 
-		// If command requires authentication and the client was not directly provided in the bootstrap config, we create a new client and overwrite the existing one
+		if err := dryRunUnsupportedError(ctx, cmd); err != nil {
+			return err
+		}
+
+		if err := meta.resultListOptions.checkCommand(cmd); err != nil {
+			return err
+		}
+
+		// If command requires authentication and the client was not directly provided in the bootstrap config, a new client
+		// replaces the existing one when the command first uses it, see lazyClient.
 		if !cmd.AllowAnonymousClient && !meta.isClientFromBootstrapConfig {
-			client, err := meta.Platform.CreateClient(meta.httpClient, ExtractConfigPath(ctx), ExtractProfileName(ctx))
-			if err != nil {
-				return createClientError(err)
-			}
-			meta.Client = client
+			meta.lazyClient = &lazyClient{}
 		}
 
 		// If command has no Run method there is nothing to do.
@@ -36,7 +42,17 @@ func cobraRun(ctx context.Context, cmd *Command) func(*cobra.Command, []string)
 			return nil
 		}
 
-		// Apply default values on missing args.
+		// The deadline applies to all positional args, including the wait for resources.
+		ctx, cancel := withCommandTimeout(ctx)
+		defer cancel()
+
+		// Apply the defaults of the CLI config, then default values, on missing args.
+		rawArgs, err := applyConfigDefaults(ctx, cmd, rawArgs)
+		if err != nil {
+			return err
+		}
+		// Timeouts given by the user narrow the deadline of --timeout, unlike the default value of the arg.
+		_, meta.timeoutArg = rawArgs.Get("timeout")
 		rawArgs = ApplyDefaultValues(ctx, cmd.ArgSpecs, rawArgs)
 
 		positionalArgSpec := cmd.ArgSpecs.GetPositionalArg()
@@ -48,6 +64,11 @@ func cobraRun(ctx context.Context, cmd *Command) func(*cobra.Command, []string)
 				return err
 			}
 
+			data, err = meta.resultListOptions.apply(data)
+			if err != nil {
+				return err
+			}
+
 			meta.result = data
 			return nil
 		}
@@ -88,7 +109,22 @@ func cobraRun(ctx context.Context, cmd *Command) func(*cobra.Command, []string)
 				return err
 			}
 
+			result, err = meta.resultListOptions.apply(result)
+			if err != nil {
+				return err
+			}
+
 			results = append(results, result)
+		} else if meta.parallel > 1 {
+			var err error
+			results, err = runPositionalArgs(ctx, cobraCmd, cmd, rawArgs, positionalArgSpec.Name, positionalArgs, meta.parallel)
+			if err != nil {
+				// The results of the runs that succeeded are printed with the error.
+				if len(results) > 0 {
+					meta.result = results
+				}
+				return err
+			}
 		} else {
 			for _, positionalArg := range positionalArgs {
 				rawArgsWithPositional := rawArgs.Add(positionalArgSpec.Name, positionalArg)
@@ -98,6 +134,11 @@ func cobraRun(ctx context.Context, cmd *Command) func(*cobra.Command, []string)
 					return err
 				}
 
+				result, err = meta.resultListOptions.apply(result)
+				if err != nil {
+					return err
+				}
+
 				results = append(results, result)
 			}
 		}
@@ -162,24 +203,43 @@ func run(ctx context.Context, cobraCmd *cobra.Command, cmd *Command, rawArgs []s
 		return runWeb(cmd, cmdArgs)
 	}
 
+	// The client may have been created for the args, e.g. for its default zone.
+	if clientErr := extractMeta(ctx).lazyClient.error(); clientErr != nil {
+		return nil, createClientError(clientErr)
+	}
+
 	// execute the command
 	interceptor := CombineCommandInterceptor(
+		tagPolicyInterceptor,
+		confirmInterceptor,
+		hooksInterceptor,
+		listStreamingInterceptor,
 		sdkStdErrorInterceptor,
 		sdkStdTypeInterceptor,
 		cmd.Interceptor,
 	)
 
 	data, err := interceptor(ctx, cmdArgs, func(ctx context.Context, argsI interface{}) (i interface{}, err error) {
-		return cmd.Run(ctx, argsI)
+		i, err = cmd.Run(ctx, argsI)
+		// Requests sent without the client of the profile fail, the error of its creation explains why.
+		if clientErr := extractMeta(ctx).lazyClient.error(); clientErr != nil {
+			return nil, createClientError(clientErr)
+		}
+		return i, err
 	})
+	// Cached responses may be outdated even if the command failed halfway, but not if it was cancelled.
+	if cmd.isMutating() && !extractMeta(ctx).dryRun && !isCommandCancelled(err) {
+		ExtractCache(ctx).Update(cmd.Namespace)
+	}
 	if err != nil {
-		return nil, err
+		return nil, timeoutError(ctx, err)
 	}
+	// Resources are not created in dry-run mode, there is nothing to wait for.
 	waitFlag, err := cobraCmd.PersistentFlags().GetBool("wait")
-	if err == nil && cmd.WaitFunc != nil && waitFlag {
+	if err == nil && cmd.WaitFunc != nil && waitFlag && !extractMeta(ctx).dryRun {
 		data, err = cmd.WaitFunc(ctx, cmdArgs, data)
 		if err != nil {
-			return nil, err
+			return nil, timeoutError(ctx, err)
 		}
 	}
 	return data, nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 
@@ -37,6 +38,17 @@ type Command struct {
 	// DisableAfterChecks disable checks that run after the command to avoid superfluous message
 	DisableAfterChecks bool
 
+	// DisableHistory prevents the command from being recorded in the history, e.g. for commands run by other programs.
+	DisableHistory bool
+
+	// Destructive asks for a confirmation before running the command in a terminal, see confirmInterceptor.
+	// It is inferred for the verbs of destructiveVerbs.
+	Destructive bool
+
+	// LocalEffects is true for commands that modify local files or run programs, e.g. config set or instance server ssh.
+	// They are refused in dry-run mode as only the API calls are intercepted.
+	LocalEffects bool
+
 	// Hidden hides the command form usage and auto-complete.
 	Hidden bool
 
@@ -89,6 +101,12 @@ type Command struct {
 	Aliases []string
 	// cache command path
 	path string
+	// pluginPath is the executable run for commands provided by a plugin, see plugin.go
+	pluginPath string
+	// pluginManifestLoaded is true once the commands listed in the manifest of the plugin were added
+	pluginManifestLoaded bool
+	// runOverridden is true if Override replaced the Run of the command, see listStreamingInterceptor
+	runOverridden bool
 
 	// Groups contains a list of groups IDs
 	Groups []string
@@ -113,8 +131,12 @@ const indexCommandSeparator = "."
 
 // Override replaces or mutates the Command via a builder function.
 func (c *Command) Override(builder func(command *Command) *Command) {
+	run := reflect.ValueOf(c.Run).Pointer()
 	// Assign the value in case the builder creates a new Command object.
 	*c = *builder(c)
+	if reflect.ValueOf(c.Run).Pointer() != run {
+		c.runOverridden = true
+	}
 }
 
 func (c *Command) getPath() string {
@@ -295,6 +317,19 @@ func (c *Commands) find(path ...string) (*Command, bool) {
 	return nil, false
 }
 
+// words returns the namespaces, resources, verbs and aliases of the commands.
+func (c *Commands) words() map[string]bool {
+	words := map[string]bool{}
+	for _, command := range c.commands {
+		for _, word := range append([]string{command.Namespace, command.Resource, command.Verb}, command.Aliases...) {
+			if word != "" {
+				words[word] = true
+			}
+		}
+	}
+	return words
+}
+
 // GetSortedCommand returns a slice of commands sorted alphabetically
 func (c *Commands) GetSortedCommand() []*Command {
 	commands := make([]*Command, len(c.commands))
@@ -333,6 +368,34 @@ func (c *Command) getHumanMarshalerOpt() *human.MarshalOpt {
 	return nil
 }
 
+// readOnlyVerbs lists the verbs of commands that do not modify resources.
+var readOnlyVerbs = map[string]bool{
+	"get":  true,
+	"list": true,
+	"wait": true,
+}
+
+// isMutating returns true if the command may modify the resources of its namespace.
+// Commands without verb are helpers (e.g. scw info) and are considered read-only.
+func (c *Command) isMutating() bool {
+	if c.Verb == "" || readOnlyVerbs[c.Verb] {
+		return false
+	}
+	return !strings.HasPrefix(c.Verb, "get-") && !strings.HasPrefix(c.Verb, "list-")
+}
+
+// destructiveVerbs lists the verbs of commands that delete resources.
+var destructiveVerbs = map[string]bool{
+	"delete":    true,
+	"terminate": true,
+	"purge":     true,
+}
+
+// isDestructive returns true if the command must be confirmed before it runs.
+func (c *Command) isDestructive() bool {
+	return c.Destructive || destructiveVerbs[c.Verb]
+}
+
 // get a signature to sort commands
 func (c *Command) signature() string {
 	return c.Namespace + " " + c.Resource + " " + c.Verb + " " + c.Short
@@ -374,6 +437,10 @@ func (c *Commands) AliasIsValidCommandChild(command *Command, alias alias.Alias)
 func (c *Commands) addAliases(command *Command, aliases []alias.Alias) {
 	names := make([]string, 0, len(aliases))
 	for i := range aliases {
+		// Aliases are applied again when commands are bootstrapped in-process.
+		if slices.Contains(command.Aliases, aliases[i].Name) {
+			continue
+		}
 		if c.AliasIsValidCommandChild(command, aliases[i]) && command.MatchAlias(aliases[i]) {
 			names = append(names, aliases[i].Name)
 		}
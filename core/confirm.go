@@ -0,0 +1,169 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/cache"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/strcase"
+)
+
+// errCommandCancelled is returned when a confirmation is refused, nothing was modified.
+var errCommandCancelled = errors.New("command cancelled")
+
+// confirmMutex prevents prompts from mixing when commands run concurrently, see --parallel.
+var confirmMutex sync.Mutex
+
+// confirmInterceptor asks for a confirmation before running a destructive command, see CanConfirm.
+func confirmInterceptor(ctx context.Context, argsI interface{}, runner CommandRunner) (interface{}, error) {
+	meta := extractMeta(ctx)
+	if meta.command == nil || !meta.command.isDestructive() || !CanConfirm(ctx) {
+		return runner(ctx, argsI)
+	}
+
+	prompt := confirmPrompt(ctx, meta.command, argsI)
+
+	confirmMutex.Lock()
+	confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+		Ctx:          ctx,
+		Prompt:       prompt,
+		DefaultValue: false,
+	})
+	confirmMutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if !confirmed {
+		return nil, &CliError{
+			Err:  errCommandCancelled,
+			Hint: "Use --yes to run destructive commands without confirmation",
+		}
+	}
+
+	return runner(ctx, argsI)
+}
+
+// isCommandCancelled returns true if err is returned because a confirmation was refused.
+func isCommandCancelled(err error) bool {
+	cliErr := (*CliError)(nil)
+	return errors.As(err, &cliErr) && cliErr.Err == errCommandCancelled //nolint:errorlint
+}
+
+// CanConfirm returns true if confirmations are asked, i.e. when the user can answer them in a terminal.
+// They are skipped with --yes and with skip_confirmation in the CLI config.
+// They are skipped in dry-run mode too as nothing is modified: commands with local effects are refused and the
+// requests modifying resources are not sent.
+// Commands asking questions of their own about destructive operations should skip them too.
+func CanConfirm(ctx context.Context) bool {
+	meta := extractMeta(ctx)
+	if meta.yes || meta.dryRun || (meta.CliConfig != nil && meta.CliConfig.SkipConfirmation) {
+		return false
+	}
+	return interactive.CanPrompt(ctx, meta.stdin)
+}
+
+// confirmPrompt describes the resource affected by a command, e.g. "Do you want to delete server my-server (11111111-1111-1111-1111-111111111111)?".
+func confirmPrompt(ctx context.Context, cmd *Command, argsI interface{}) string {
+	target := cmd.GetCommandLine("")
+	if cmd.Resource != "" && cmd.Verb != "" {
+		target = cmd.Verb + " " + cmd.Resource
+	}
+
+	positionalArgSpec := cmd.ArgSpecs.GetPositionalArg()
+	if positionalArgSpec == nil {
+		return fmt.Sprintf("Do you want to %s?", target)
+	}
+	values, err := GetValuesForFieldByName(reflect.ValueOf(argsI), strings.Split(strcase.ToPublicGoName(positionalArgSpec.Name), "."))
+	if err != nil || len(values) != 1 {
+		return fmt.Sprintf("Do you want to %s?", target)
+	}
+	id := fmt.Sprint(values[0].Interface())
+
+	if name := resolveResourceName(ctx, cmd, argsI); name != "" && name != id {
+		return fmt.Sprintf("Do you want to %s %s (%s)?", target, name, id)
+	}
+	return fmt.Sprintf("Do you want to %s %s?", target, id)
+}
+
+// resolveResourceName returns the name of the resource affected by a command, using the get command of the same resource.
+// The get command receives the args of the command with the same name, e.g. zone and server-id.
+// An empty name is returned if the resource cannot be fetched or has no name.
+// Names are cached by profile and get args, the cache of the namespace is invalidated by the command if it modifies it.
+func resolveResourceName(ctx context.Context, cmd *Command, argsI interface{}) string {
+	getCmd := ExtractCommands(ctx).Find(cmd.Namespace, cmd.Resource, "get")
+	if getCmd == nil || getCmd.Run == nil || getCmd.ArgsType == nil || cmd.Resource == "" {
+		return ""
+	}
+
+	value := indirectValue(reflect.ValueOf(argsI))
+	getArgs := reflect.New(getCmd.ArgsType)
+	if value.Kind() != reflect.Struct || getArgs.Elem().Kind() != reflect.Struct {
+		return ""
+	}
+	for i := 0; i < getArgs.Elem().NumField(); i++ {
+		field := getArgs.Elem().Type().Field(i)
+		argField := value.FieldByName(field.Name)
+		if field.IsExported() && argField.IsValid() && argField.Type().AssignableTo(field.Type) {
+			getArgs.Elem().Field(i).Set(argField)
+		}
+	}
+
+	responseCache := ExtractCache(ctx)
+	cacheKey := ""
+	if rawGetArgs, err := json.Marshal(getArgs.Interface()); err == nil {
+		cacheKey = fmt.Sprintf("%s %s %s", ExtractProfileName(ctx), getCmd.getPath(), rawGetArgs)
+		name := ""
+		if responseCache.Get(getCmd.Namespace, cacheKey, &name) {
+			return name
+		}
+	}
+
+	runner := getCmd.Run
+	if getCmd.Interceptor != nil {
+		runner = func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			return getCmd.Interceptor(ctx, argsI, getCmd.Run)
+		}
+	}
+	result, err := runner(ctx, getArgs.Interface())
+	if err != nil {
+		ExtractLogger(ctx).Debugf("cannot get the name of the resource to confirm: %s\n", err)
+		return ""
+	}
+
+	name := resourceName(result)
+	if cacheKey != "" {
+		responseCache.Set(getCmd.Namespace, cacheKey, name, cache.DefaultTTL)
+	}
+	return name
+}
+
+// resourceName returns the Name field of a resource, directly or in one of its fields, e.g. GetServerResponse.Server.
+func resourceName(result interface{}) string {
+	value := indirectValue(reflect.ValueOf(result))
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+	if name := structStringField(value, "Name"); name != "" {
+		return name
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Type().Field(i).IsExported() {
+			continue
+		}
+		field := indirectValue(value.Field(i))
+		if field.Kind() != reflect.Struct {
+			continue
+		}
+		if name := structStringField(field, "Name"); name != "" {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+type confirmFlower struct {
+	ID   string
+	Name string
+}
+
+type confirmFlowerRequest struct {
+	FlowerID string
+}
+
+// confirmCommands returns the commands of the confirm tests, getCalls counts the calls to the get command.
+func confirmCommands(getCalls *int) *core.Commands {
+	return core.NewCommands(
+		&core.Command{
+			Namespace: "test",
+			Resource:  "flower",
+			Verb:      "get",
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name:       "flower-id",
+					Positional: true,
+				},
+			},
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(confirmFlowerRequest{}),
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				*getCalls++
+				return &confirmFlower{ID: argsI.(*confirmFlowerRequest).FlowerID, Name: "rose"}, nil
+			},
+		},
+		&core.Command{
+			Namespace: "test",
+			Resource:  "flower",
+			Verb:      "delete",
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name:       "flower-id",
+					Positional: true,
+				},
+			},
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(confirmFlowerRequest{}),
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				return &core.SuccessResult{Resource: "flower", Verb: "delete"}, nil
+			},
+		},
+	)
+}
+
+func Test_Confirm(t *testing.T) {
+	commands := confirmCommands(new(int))
+
+	t.Run("confirmed", core.Test(&core.TestConfig{
+		Commands:            commands,
+		Cmd:                 "scw test flower delete 11111111-1111-1111-1111-111111111111",
+		PromptResponseMocks: []string{"y"},
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("cancelled", core.Test(&core.TestConfig{
+		Commands:            commands,
+		Cmd:                 "scw test flower delete 11111111-1111-1111-1111-111111111111",
+		PromptResponseMocks: []string{"n"},
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	t.Run("yes flag", core.Test(&core.TestConfig{
+		Commands:            commands,
+		Cmd:                 "scw test flower delete 11111111-1111-1111-1111-111111111111 -y",
+		PromptResponseMocks: []string{"n"},
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("skip confirmation in config", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: func(ctx *core.BeforeFuncCtx) error {
+			configDir := filepath.Join(ctx.OverrideEnv["HOME"], ".config", "scw")
+			err := os.MkdirAll(configDir, 0o700)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(filepath.Join(configDir, "cli.yaml"), []byte("skip_confirmation: true\n"), 0o600)
+		},
+		Cmd:                 "scw test flower delete 11111111-1111-1111-1111-111111111111",
+		PromptResponseMocks: []string{"n"},
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("dry run", core.Test(&core.TestConfig{
+		Commands:            commands,
+		Cmd:                 "scw test flower delete 11111111-1111-1111-1111-111111111111 --dry-run",
+		PromptResponseMocks: []string{"n"},
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+}
+
+func Test_ConfirmCachedName(t *testing.T) {
+	getCalls := 0
+	commands := confirmCommands(&getCalls)
+	// Both commands use the same cache.
+	overrideEnv := map[string]string{
+		scw.ScwCacheDirEnv: t.TempDir(),
+	}
+
+	t.Run("cancelled", core.Test(&core.TestConfig{
+		Commands:            commands,
+		OverrideEnv:         overrideEnv,
+		DisableParallel:     true,
+		Cmd:                 "scw test flower delete 11111111-1111-1111-1111-111111111111",
+		PromptResponseMocks: []string{"n"},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	// The name is cached as the cancelled command modified nothing.
+	t.Run("confirmed", core.Test(&core.TestConfig{
+		Commands:            commands,
+		OverrideEnv:         overrideEnv,
+		DisableParallel:     true,
+		Cmd:                 "scw test flower delete 11111111-1111-1111-1111-111111111111",
+		PromptResponseMocks: []string{"y"},
+		Check: core.TestCheckCombine(
+			func(t *testing.T, _ *core.CheckFuncCtx) {
+				t.Helper()
+				assert.Equal(t, 1, getCalls)
+			},
+			core.TestCheckExitCode(0),
+		),
+	}))
+}
@@ -6,8 +6,12 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"sync"
+	"time"
 
+	"github.com/scaleway/scaleway-cli/v2/core/human"
 	"github.com/scaleway/scaleway-cli/v2/internal/alias"
+	"github.com/scaleway/scaleway-cli/v2/internal/cache"
 	cliConfig "github.com/scaleway/scaleway-cli/v2/internal/config"
 	"github.com/scaleway/scaleway-cli/v2/internal/platform"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -34,7 +38,24 @@ type Meta struct {
 	stderr                      io.Writer
 	stdin                       io.Reader
 	result                      interface{}
+	cache                       *cache.Cache
+	humanPreferences            *human.Preferences // preferences of the human marshaler from the CLI config, see applyHumanPreferences
+	resultListOptions           *resultListOptions
+	listStreaming               *listStreamingTransport
+	dryRun                      bool
+	yes                         bool
+	parallel                    int
+	timeout                     time.Duration
+	timeoutArg                  bool // the command was given a timeout arg, which narrows the deadline of timeout, see WaitTimeout
+	waitInterval                time.Duration
+	disableHistory              bool
+	secretArgValues             []string // values of the secret args of the command, redacted in the history, see ArgSpec.Secret
 	httpClient                  *http.Client
+	secretKeys                  *sync.Map   // outputs of secret_key_command cached for the process, see SecretKeyFromCommand
+	lazyClient                  *lazyClient // creates the client of the profile when the command first uses it
+	workDir                     string
+	projectConfig               *cliConfig.ProjectConfig
+	aliases                     *alias.Config // aliases of the CLI config and of the project config file
 	isClientFromBootstrapConfig bool
 	BetaMode                    bool
 }
@@ -77,10 +98,20 @@ func ExtractCliConfig(ctx context.Context) *cliConfig.Config {
 	return extractMeta(ctx).CliConfig
 }
 
+// ExtractAliases returns the aliases of the CLI config with the ones of the project config file.
+// Aliases are saved with ExtractCliConfig(ctx).Alias so that the ones of the project config file are not.
 func ExtractAliases(ctx context.Context) *alias.Config {
+	if aliases := extractMeta(ctx).aliases; aliases != nil {
+		return aliases
+	}
 	return ExtractCliConfig(ctx).Alias
 }
 
+// ExtractProjectConfig returns the project config file found from the working directory, it is nil if there is none.
+func ExtractProjectConfig(ctx context.Context) *cliConfig.ProjectConfig {
+	return extractMeta(ctx).projectConfig
+}
+
 func GetOrganizationIDFromContext(ctx context.Context) string {
 	client := ExtractClient(ctx)
 	organizationID, _ := client.GetDefaultOrganizationID()
@@ -93,8 +124,12 @@ func GetProjectIDFromContext(ctx context.Context) string {
 	return projectID
 }
 
+// ExtractClient returns the client of the command.
+// The client of the profile is created the first time it is extracted, see lazyClient.
 func ExtractClient(ctx context.Context) *scw.Client {
-	return extractMeta(ctx).Client
+	meta := extractMeta(ctx)
+	meta.lazyClient.create(ctx)
+	return meta.Client
 }
 
 func ExtractLogger(ctx context.Context) *Logger {
@@ -127,6 +162,15 @@ func ExtractUserHomeDir(ctx context.Context) string {
 	return ExtractEnv(ctx, "HOME")
 }
 
+// responseCacheDirName is the folder of the cache directory where command responses are persisted.
+const responseCacheDirName = "responses"
+
+// ExtractCache returns the cache of command responses persisted in the cache directory.
+// It is nil in contexts that were not created by Bootstrap, its methods then do nothing.
+func ExtractCache(ctx context.Context) *cache.Cache {
+	return extractMeta(ctx).cache
+}
+
 func ExtractCacheDir(ctx context.Context) string {
 	env := ExtractEnv(ctx, scw.ScwCacheDirEnv)
 	if env != "" {
@@ -154,6 +198,11 @@ func ExtractProfileName(ctx context.Context) string {
 		return env
 	}
 
+	// Handle profile in project config file
+	if projectConfig := ExtractProjectConfig(ctx); projectConfig != nil && projectConfig.Profile != "" {
+		return projectConfig.Profile
+	}
+
 	// Handle active_profile in config file
 	configPath := ExtractConfigPath(ctx)
 	config, err := scw.LoadConfigFromPath(configPath)
@@ -194,7 +243,8 @@ func ExtractCliConfigPath(ctx context.Context) string {
 func ReloadClient(ctx context.Context) error {
 	var err error
 	meta := extractMeta(ctx)
-	meta.Client, err = meta.Platform.CreateClient(meta.httpClient, ExtractConfigPath(ctx), ExtractProfileName(ctx))
+	meta.Client, err = createClient(ctx)
+	meta.lazyClient = nil
 	return err
 }
 
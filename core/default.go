@@ -2,6 +2,8 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/args"
@@ -63,6 +65,81 @@ func ApplyDefaultValues(ctx context.Context, argSpecs ArgSpecs, rawArgs args.Raw
 	return rawArgs
 }
 
+// ConfigDefaults returns the values of the defaults section of the CLI config for a command, sorted by arg name.
+// They are keyed by command path, e.g. instance.server.create.
+func ConfigDefaults(ctx context.Context, cmd *Command) args.RawArgs {
+	cliConfig := ExtractCliConfig(ctx)
+	if cliConfig == nil || len(cliConfig.Defaults[cmd.getPath()]) == 0 {
+		return nil
+	}
+
+	defaults := cliConfig.Defaults[cmd.getPath()]
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rawArgs := args.RawArgs(nil)
+	for _, name := range names {
+		rawArgs = rawArgs.Add(name, defaults[name])
+	}
+	return rawArgs
+}
+
+// applyConfigDefaults adds the defaults of the CLI config to the args that are not given.
+// It is called before ApplyDefaultValues so that they have priority over the default values of the command.
+func applyConfigDefaults(ctx context.Context, cmd *Command, rawArgs args.RawArgs) (args.RawArgs, error) {
+	for _, rawArg := range ConfigDefaults(ctx, cmd) {
+		name, value, _ := strings.Cut(rawArg, "=")
+		argSpec := argSpecOfRawArg(cmd.ArgSpecs, name)
+		switch {
+		case argSpec == nil:
+			names := make([]string, 0, len(cmd.ArgSpecs))
+			for _, argSpec := range cmd.ArgSpecs {
+				if !argSpec.Positional {
+					names = append(names, argSpec.Name)
+				}
+			}
+			return nil, &CliError{
+				Err:  fmt.Errorf("unknown argument '%s' in the defaults of %s in the CLI config", name, cmd.getPath()),
+				Hint: "Valid arguments are: " + strings.Join(names, ", "),
+			}
+		case argSpec.Positional:
+			return nil, &CliError{
+				Err:  fmt.Errorf("positional argument '%s' in the defaults of %s in the CLI config", name, cmd.getPath()),
+				Hint: "Positional arguments cannot have defaults, remove it from the defaults section of the CLI config",
+			}
+		}
+		if _, exist := rawArgs.Get(name); !exist {
+			rawArgs = rawArgs.Add(name, value)
+		}
+	}
+	return rawArgs, nil
+}
+
+// argSpecOfRawArg returns the ArgSpec of an arg name, e.g. the ArgSpec tags.{index} for tags.0.
+func argSpecOfRawArg(argSpecs ArgSpecs, name string) *ArgSpec {
+	parts := strings.Split(name, ".")
+	for _, argSpec := range argSpecs {
+		specParts := strings.Split(argSpec.Name, ".")
+		if len(specParts) != len(parts) {
+			continue
+		}
+		matches := true
+		for i := range parts {
+			if specParts[i] != parts[i] && specParts[i] != sliceSchema && specParts[i] != mapSchema {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return argSpec
+		}
+	}
+	return nil
+}
+
 // GetRandomName returns a random name prefixed for the CLI.
 func GetRandomName(prefix string) string {
 	return namegenerator.GetRandomName("cli", prefix)
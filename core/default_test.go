@@ -2,6 +2,11 @@ package core_test
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/alecthomas/assert"
@@ -86,3 +91,92 @@ func Test_ApplyDefaultValues(t *testing.T) {
 		},
 	}))
 }
+
+type defaultServerArgs struct {
+	Name string
+	Type string
+	Tags []string
+}
+
+func Test_ConfigDefaults(t *testing.T) {
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "server",
+			Verb:                 "create",
+			AllowAnonymousClient: true,
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name:    "name",
+					Default: core.DefaultValueSetter("server"),
+				},
+				{
+					Name:    "type",
+					Default: core.DefaultValueSetter("DEV1-S"),
+				},
+				{
+					Name: "tags.{index}",
+				},
+			},
+			ArgsType: reflect.TypeOf(defaultServerArgs{}),
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				args := argsI.(*defaultServerArgs)
+				return fmt.Sprintf("%s %s [%s]", args.Name, args.Type, strings.Join(args.Tags, ", ")), nil
+			},
+		},
+	)
+
+	writeCliConfig := func(content string) core.BeforeFunc {
+		return func(ctx *core.BeforeFuncCtx) error {
+			configDir := filepath.Join(ctx.OverrideEnv["HOME"], ".config", "scw")
+			err := os.MkdirAll(configDir, 0o700)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(filepath.Join(configDir, "cli.yaml"), []byte(content), 0o600)
+		}
+	}
+	defaultsConfig := writeCliConfig(`defaults:
+  test.server.create:
+    type: PRO2-S
+    tags.0: team-x
+`)
+
+	t.Run("defaults", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: defaultsConfig,
+		Cmd:        "scw test server create",
+		Check:      core.TestCheckGolden(),
+	}))
+
+	t.Run("explicit args have priority", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: defaultsConfig,
+		Cmd:        "scw test server create type=PRO2-M tags.0=team-y tags.1=web",
+		Check:      core.TestCheckGolden(),
+	}))
+
+	t.Run("usage", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: defaultsConfig,
+		Cmd:        "scw test server create -h",
+		Check:      core.TestCheckGolden(),
+	}))
+
+	t.Run("invalid argument", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: writeCliConfig(`defaults:
+  test.server.create:
+    size: 20
+`),
+		Cmd: "scw test server create",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+}
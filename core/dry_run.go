@@ -0,0 +1,165 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// dryRunResourceID is the ID of the resources returned by requests intercepted in dry-run mode.
+const dryRunResourceID = "00000000-0000-0000-0000-000000000000"
+
+// dryRunTransport prints the requests that would modify resources instead of sending them.
+//
+// GET requests are sent as usual so that commands can still read the resources they need.
+// Other requests are answered with a synthesized resource having a fake ID, or an empty response for DELETE
+// requests. Commands made of several steps (e.g. creating a volume then a server) can go on with the fake ID,
+// reading a resource with this ID is intercepted too.
+type dryRunTransport struct {
+	transport http.RoundTripper
+	writer    io.Writer
+
+	mu sync.Mutex
+}
+
+func newDryRunTransport(transport http.RoundTripper, writer io.Writer) *dryRunTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &dryRunTransport{
+		transport: transport,
+		writer:    writer,
+	}
+}
+
+func (t *dryRunTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	isRead := request.Method == http.MethodGet || request.Method == http.MethodHead || request.Method == http.MethodOptions
+	if isRead && !strings.Contains(request.URL.Path, dryRunResourceID) {
+		return t.transport.RoundTrip(request)
+	}
+
+	body := []byte(nil)
+	if request.Body != nil && request.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		_ = request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := t.print(request, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Method == http.MethodDelete {
+		return dryRunResponse(request, http.StatusNoContent, nil), nil
+	}
+	return dryRunResponse(request, http.StatusOK, dryRunResponseBody(request.URL.Path, body)), nil
+}
+
+// print writes the method, the URL and the JSON body of an intercepted request.
+func (t *dryRunTransport) print(request *http.Request, body []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, err := fmt.Fprintf(t.writer, "[dry-run] %s %s\n", request.Method, request.URL.Redacted())
+	if err != nil || len(bytes.TrimSpace(body)) == 0 {
+		return err
+	}
+
+	indented := &bytes.Buffer{}
+	if json.Indent(indented, body, "", "  ") != nil {
+		// Bodies that are not JSON (e.g. files) are not printed.
+		_, err = fmt.Fprintf(t.writer, "<%d bytes>\n", len(body))
+		return err
+	}
+	_, err = fmt.Fprintln(t.writer, indented.String())
+	return err
+}
+
+// dryRunResponseBody returns the body of the synthesized response.
+//
+// The transport does not know the type the response is decoded into: only the fields having the same type in
+// requests and resources, the ID and the name, are set. The resource is returned both as is and wrapped in a field
+// named after the resource of the path, e.g. "server" for /instance/v1/zones/fr-par-1/servers, so that responses
+// like {"server": {...}} are decoded too. Unknown fields are ignored when decoding.
+func dryRunResponseBody(path string, requestBody []byte) []byte {
+	request := map[string]interface{}{}
+	_ = json.Unmarshal(requestBody, &request)
+
+	resource := map[string]interface{}{
+		"id": dryRunResourceID,
+	}
+	if name, isString := request["name"].(string); isString {
+		resource["name"] = name
+	}
+
+	response := map[string]interface{}{}
+	for key, value := range resource {
+		response[key] = value
+	}
+	if wrapper := dryRunResourceName(path); wrapper != "" {
+		response[wrapper] = resource
+	}
+
+	body, _ := json.Marshal(response)
+	return body
+}
+
+// dryRunResourceName returns the singular name of the last resource of a path, ignoring IDs and actions,
+// e.g. "ip" for /instance/v1/zones/fr-par-1/ips or "server" for /instance/v1/zones/fr-par-1/servers/<id>/action.
+func dryRunResourceName(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(segments) - 1; i > 0; i-- {
+		segment := segments[i]
+		if !strings.HasSuffix(segment, "s") || segments[i-1] == "zones" || segments[i-1] == "regions" {
+			continue
+		}
+		switch {
+		case strings.HasSuffix(segment, "ies"):
+			return strings.TrimSuffix(segment, "ies") + "y"
+		case strings.HasSuffix(segment, "sses"):
+			return strings.TrimSuffix(segment, "es")
+		default:
+			return strings.TrimSuffix(segment, "s")
+		}
+	}
+	return ""
+}
+
+func dryRunResponse(request *http.Request, statusCode int, body []byte) *http.Response {
+	header := http.Header{}
+	if body != nil {
+		header.Set("Content-Type", "application/json")
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}
+}
+
+// dryRunUnsupportedError returns an error if the command has local effects and is run with --dry-run.
+func dryRunUnsupportedError(ctx context.Context, cmd *Command) error {
+	meta := extractMeta(ctx)
+	if !meta.dryRun || !cmd.LocalEffects {
+		return nil
+	}
+	return &CliError{
+		Err:  fmt.Errorf("'%s' does not support --dry-run", cmd.GetCommandLine(meta.BinaryName)),
+		Hint: "This command modifies local files or runs programs, which --dry-run cannot intercept. Run it without --dry-run",
+	}
+}
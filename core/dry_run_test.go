@@ -0,0 +1,135 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type dryRunFlower struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func Test_DryRun(t *testing.T) {
+	newClient := func(ctx context.Context) (*scw.Client, error) {
+		return scw.NewClient(
+			scw.WithHTTPClient(core.ExtractHTTPClient(ctx)),
+			scw.WithoutAuth(),
+			scw.WithUserAgent("cli-e2e-test"),
+		)
+	}
+
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "flower",
+			Verb:                 "create",
+			ArgsType:             reflect.TypeOf(struct{}{}),
+			AllowAnonymousClient: true,
+			Run: func(ctx context.Context, _ interface{}) (interface{}, error) {
+				client, err := newClient(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				// Custom commands read the resources they create, e.g. to attach them.
+				request := &scw.ScalewayRequest{
+					Method: http.MethodPost,
+					Path:   "/test/v1/zones/fr-par-1/flowers",
+				}
+				err = request.SetBody(&dryRunFlower{Name: "rose", Color: "red"})
+				if err != nil {
+					return nil, err
+				}
+				created := &dryRunFlower{}
+				err = client.Do(request, created)
+				if err != nil {
+					return nil, err
+				}
+
+				flower := &dryRunFlower{}
+				err = client.Do(&scw.ScalewayRequest{
+					Method: http.MethodGet,
+					Path:   "/test/v1/zones/fr-par-1/flowers/" + created.ID,
+				}, flower)
+				if err != nil {
+					return nil, err
+				}
+				return created, nil
+			},
+			WaitFunc: func(_ context.Context, _, _ interface{}) (interface{}, error) {
+				return nil, errors.New("resources are not created in dry-run mode")
+			},
+		},
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "flower",
+			Verb:                 "delete",
+			ArgsType:             reflect.TypeOf(struct{}{}),
+			AllowAnonymousClient: true,
+			Run: func(ctx context.Context, _ interface{}) (interface{}, error) {
+				client, err := newClient(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				flower := &dryRunFlower{}
+				err = client.Do(&scw.ScalewayRequest{
+					Method: http.MethodGet,
+					Path:   "/test/v1/zones/fr-par-1/flowers/rose",
+				}, flower)
+				if err != nil {
+					return nil, err
+				}
+
+				err = client.Do(&scw.ScalewayRequest{
+					Method: http.MethodDelete,
+					Path:   "/test/v1/zones/fr-par-1/flowers/" + flower.ID,
+				}, nil)
+				if err != nil {
+					return nil, err
+				}
+				return &core.SuccessResult{Resource: "flower", Verb: "delete"}, nil
+			},
+		},
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "flower",
+			Verb:                 "plant",
+			ArgsType:             reflect.TypeOf(struct{}{}),
+			AllowAnonymousClient: true,
+			LocalEffects:         true,
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return nil, errors.New("commands with local effects do not run in dry-run mode")
+			},
+		},
+	)
+
+	t.Run("create", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower create --dry-run --wait",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("delete", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower delete --dry-run",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("local effects", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower plant --dry-run",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+}
@@ -3,6 +3,9 @@ package core
 import (
 	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/profiles"
 )
 
 func MissingRequiredArgumentError(argumentName string) *CliError {
@@ -25,6 +28,20 @@ func InvalidSecretKeyError(value string) *CliError {
 	}
 }
 
+func InvalidSecretKeyCacheError(value string) *CliError {
+	return &CliError{
+		Err:  fmt.Errorf("invalid secret_key_cache '%v'", value),
+		Hint: "secret_key_cache should be one of: " + strings.Join(profiles.SecretKeyCaches, ", ") + ".",
+	}
+}
+
+func InvalidSecretKeyCommandError(value string) *CliError {
+	return &CliError{
+		Err:  fmt.Errorf("invalid secret_key_command '%v'", value),
+		Hint: "secret_key_command should be a command line printing the secret key, e.g. pass show scw/prod.",
+	}
+}
+
 func InvalidAccessKeyError(value string) *CliError {
 	return &CliError{
 		Err:  fmt.Errorf("invalid access_key '%v'", value),
@@ -24,7 +24,12 @@ func ExecCmd(ctx context.Context, cmd *exec.Cmd) (exitCode int, err error) {
 		cmd.Stdin = os.Stdin
 	}
 
-	cmd.Stdout = meta.stdout
-	cmd.Stderr = meta.stderr
+	// Commands can print their output elsewhere, e.g. hooks print on stderr.
+	if cmd.Stdout == nil {
+		cmd.Stdout = meta.stdout
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = meta.stderr
+	}
 	return meta.OverrideExec(cmd)
 }
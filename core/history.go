@@ -0,0 +1,337 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+)
+
+const (
+	historyFileName = "history.jsonl"
+
+	// historyMaxFileSize is the size above which the oldest half of the history is dropped.
+	historyMaxFileSize = 4 << 20
+
+	// historyMaxTailSize is the size of the end of the history read to number a new entry.
+	historyMaxTailSize = 64 << 10
+
+	// historyMaxResourceIDs limits the IDs recorded for commands returning many resources, e.g. lists.
+	historyMaxResourceIDs = 100
+
+	historyRedactedValue = "<redacted>"
+)
+
+// HistoryEntry is a command recorded in the history, one JSON line per command.
+type HistoryEntry struct {
+	// Number identifies the entry, it is incremented for each command starting at 1.
+	// It does not change when the oldest entries are dropped, see trimHistory.
+	// Entries recorded without number are numbered after the previous entry.
+	Number int `json:"number,omitempty"`
+
+	Time time.Time `json:"time"`
+
+	// Command is the command path, e.g. instance server create.
+	Command string `json:"command"`
+
+	// Args are the args of the command after alias resolution, without the binary name.
+	Args []string `json:"args"`
+
+	// Redacted is set when the value of secret args was removed from Args.
+	Redacted bool `json:"redacted,omitempty"`
+
+	Profile     string   `json:"profile"`
+	ExitCode    int      `json:"exit_code"`
+	ResourceIDs []string `json:"resource_ids,omitempty"`
+}
+
+// historyPath returns the path of the history file.
+func historyPath(ctx context.Context) string {
+	return filepath.Join(ExtractCacheDir(ctx), historyFileName)
+}
+
+// ReadHistory returns the entries of the history, oldest first.
+// A missing history file is an empty history, malformed lines are skipped.
+func ReadHistory(ctx context.Context) ([]*HistoryEntry, error) {
+	content, err := os.ReadFile(historyPath(ctx))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read history: %w", err)
+	}
+
+	return parseHistory(content)
+}
+
+func parseHistory(content []byte) ([]*HistoryEntry, error) {
+	entries := []*HistoryEntry(nil)
+	number := 0
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, historyMaxFileSize)
+	for scanner.Scan() {
+		entry := &HistoryEntry{}
+		if json.Unmarshal(scanner.Bytes(), entry) != nil {
+			continue
+		}
+		if entry.Number == 0 {
+			entry.Number = number + 1
+		}
+		number = entry.Number
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// lastHistoryNumber returns the number of the last entry of the history, 0 if it is empty.
+// Only the end of the history is read, unless its last entry was recorded without number.
+func lastHistoryNumber(path string) (int, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	offset := max(info.Size()-historyMaxTailSize, 0)
+	tail := make([]byte, info.Size()-offset)
+	_, err = f.ReadAt(tail, offset)
+	if err != nil {
+		return 0, err
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(tail), []byte("\n"))
+	for i := len(lines) - 1; i >= 0; i-- {
+		entry := &HistoryEntry{}
+		if json.Unmarshal(lines[i], entry) != nil {
+			continue
+		}
+		if entry.Number > 0 {
+			return entry.Number, nil
+		}
+		break
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	entries, err := parseHistory(content)
+	if err != nil || len(entries) == 0 {
+		return 0, err
+	}
+	return entries[len(entries)-1].Number, nil
+}
+
+// recordHistory appends the command that was run to the history.
+// The history is best effort, failing to write it does not fail the command.
+func recordHistory(ctx context.Context, args []string, exitCode int, result interface{}) {
+	meta := extractMeta(ctx)
+	if meta.disableHistory || meta.command == nil || meta.command.Run == nil || meta.command.DisableHistory {
+		return
+	}
+
+	redactedArgs, redacted := redactArgs(args, meta.secretArgValues)
+	entry := &HistoryEntry{
+		Time:        time.Now().UTC(),
+		Command:     meta.command.GetCommandLine(""),
+		Args:        redactedArgs,
+		Redacted:    redacted,
+		Profile:     ExtractProfileName(ctx),
+		ExitCode:    exitCode,
+		ResourceIDs: resourceIDs(result),
+	}
+
+	err := appendHistory(historyPath(ctx), entry)
+	if err != nil {
+		ExtractLogger(ctx).Debugf("cannot write history: %s\n", err)
+	}
+}
+
+func appendHistory(path string, entry *HistoryEntry) error {
+	lastNumber, err := lastHistoryNumber(path)
+	if err != nil {
+		return err
+	}
+	entry.Number = lastNumber + 1
+
+	// Args are kept readable, e.g. password=<redacted>.
+	line := &bytes.Buffer{}
+	encoder := json.NewEncoder(line)
+	encoder.SetEscapeHTML(false)
+	err = encoder.Encode(entry)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(line.Bytes())
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return trimHistory(path)
+}
+
+// trimHistory drops the oldest half of the history once it is larger than historyMaxFileSize.
+// The numbers of the remaining entries are written so that they do not change.
+func trimHistory(path string) error {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() <= historyMaxFileSize {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	entries, err := parseHistory(content)
+	if err != nil {
+		return err
+	}
+
+	trimmed := &bytes.Buffer{}
+	encoder := json.NewEncoder(trimmed)
+	encoder.SetEscapeHTML(false)
+	for _, entry := range entries[len(entries)/2:] {
+		err = encoder.Encode(entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(path, trimmed.Bytes(), 0o600)
+}
+
+// redactArgs replaces the values of secret args wherever they appear, e.g. password=xxx becomes password=<redacted>.
+// Values given as positional args or as flag values, e.g. --flag xxx or --flag=xxx, are redacted too.
+func redactArgs(args []string, secretValues []string) ([]string, bool) {
+	redactedArgs := make([]string, 0, len(args))
+	redacted := false
+	for _, arg := range args {
+		for _, value := range secretValues {
+			switch {
+			case arg == value:
+				arg = historyRedactedValue
+			case strings.HasSuffix(arg, "="+value):
+				arg = strings.TrimSuffix(arg, value) + historyRedactedValue
+			default:
+				continue
+			}
+			redacted = true
+			break
+		}
+		redactedArgs = append(redactedArgs, arg)
+	}
+	return redactedArgs, redacted
+}
+
+// secretArgValues returns the values of the secret args of a command, given as name=value or as positional args.
+func secretArgValues(argSpecs ArgSpecs, rawArgs args.RawArgs) []string {
+	values := []string(nil)
+	if positionalArgSpec := argSpecs.GetPositionalArg(); positionalArgSpec != nil && positionalArgSpec.IsSecret() {
+		for _, value := range rawArgs.GetPositionalArgs() {
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	for _, arg := range rawArgs {
+		name, value, isNamed := strings.Cut(arg, "=")
+		if !isNamed || value == "" {
+			continue
+		}
+		if argSpec := argSpecOfRawArg(argSpecs, name); argSpec != nil && argSpec.IsSecret() {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// resourceIDs returns the IDs of the resources in a result.
+// A resource is a struct with an ID field, directly or in one of its fields, e.g. CreateServerResponse.Server.
+func resourceIDs(result interface{}) []string {
+	ids := []string(nil)
+	value := indirectValue(reflect.ValueOf(result))
+	if value.Kind() == reflect.Slice {
+		for i := 0; i < value.Len() && len(ids) < historyMaxResourceIDs; i++ {
+			ids = append(ids, structResourceIDs(value.Index(i))...)
+		}
+	} else {
+		ids = structResourceIDs(value)
+	}
+
+	if len(ids) > historyMaxResourceIDs {
+		ids = ids[:historyMaxResourceIDs]
+	}
+	return ids
+}
+
+func structResourceIDs(value reflect.Value) []string {
+	value = indirectValue(value)
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	if id := structStringField(value, "ID"); id != "" {
+		return []string{id}
+	}
+
+	ids := []string(nil)
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Type().Field(i).IsExported() {
+			continue
+		}
+		field := indirectValue(value.Field(i))
+		if field.Kind() != reflect.Struct {
+			continue
+		}
+		if id := structStringField(field, "ID"); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func structStringField(value reflect.Value, name string) string {
+	field := value.FieldByName(name)
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+	return field.String()
+}
+
+func indirectValue(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	cliConfig "github.com/scaleway/scaleway-cli/v2/internal/config"
+	"github.com/scaleway/scaleway-cli/v2/internal/pkg/shlex"
+)
+
+const (
+	hookStagePre  = "pre"
+	hookStagePost = "post"
+)
+
+// hookInput is sent as JSON on the standard input of hooks.
+type hookInput struct {
+	Stage   string      `json:"stage"`
+	Command string      `json:"command"`
+	Args    interface{} `json:"args"`
+	Result  interface{} `json:"result,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// hooksInterceptor runs the hooks of the CLI config matching the command.
+// A failing pre hook aborts the command. Post hooks run even if the command failed, their failures are only logged.
+// Hooks do not run in dry-run mode as they may have side effects, the pre hooks that would run are printed.
+func hooksInterceptor(ctx context.Context, argsI interface{}, runner CommandRunner) (interface{}, error) {
+	meta := extractMeta(ctx)
+	if meta.command == nil || meta.CliConfig == nil || len(meta.CliConfig.Hooks) == 0 {
+		return runner(ctx, argsI)
+	}
+
+	pre, post, err := matchHooks(meta.CliConfig.Hooks, meta.command.getPath())
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.dryRun {
+		for _, hook := range pre {
+			_, err := fmt.Fprintf(meta.stderr, "[dry-run] pre hook %s\n", hook)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return runner(ctx, argsI)
+	}
+
+	commandLine := meta.command.GetCommandLine(meta.BinaryName)
+	for _, hook := range pre {
+		err := runHook(ctx, hook, &hookInput{
+			Stage:   hookStagePre,
+			Command: commandLine,
+			Args:    argsI,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := runner(ctx, argsI)
+	input := &hookInput{
+		Stage:   hookStagePost,
+		Command: commandLine,
+		Args:    argsI,
+		Result:  result,
+	}
+	if err != nil {
+		input.Error = err.Error()
+	}
+	for _, hook := range post {
+		hookErr := runHook(ctx, hook, input)
+		if hookErr != nil {
+			ExtractLogger(ctx).Warningf("%s\n", hookErr)
+		}
+	}
+
+	return result, err
+}
+
+// matchHooks returns the pre and post hooks of the globs matching a command path, e.g. instance.server.delete.
+// Globs are matched in alphabetical order and a * never matches a dot.
+func matchHooks(hooks map[string]*cliConfig.HookConfig, commandPath string) (pre []string, post []string, err error) {
+	globs := make([]string, 0, len(hooks))
+	for glob := range hooks {
+		globs = append(globs, glob)
+	}
+	sort.Strings(globs)
+
+	for _, glob := range globs {
+		// Dots are replaced by slashes so that path.Match handles them as separators.
+		matched, err := path.Match(strings.ReplaceAll(glob, ".", "/"), strings.ReplaceAll(commandPath, ".", "/"))
+		if err != nil {
+			return nil, nil, &CliError{
+				Err:  fmt.Errorf("invalid glob '%s' in the hooks section of the CLI config: %w", glob, err),
+				Hint: "Globs are command paths separated by dots, e.g. instance.server.delete or *.*.create",
+			}
+		}
+		if !matched || hooks[glob] == nil {
+			continue
+		}
+		pre = append(pre, hooks[glob].Pre...)
+		post = append(post, hooks[glob].Post...)
+	}
+
+	return pre, post, nil
+}
+
+// runHook runs a hook with its input as JSON on the standard input.
+// The output of hooks is printed on the standard error so that it does not mix with the result of the command.
+func runHook(ctx context.Context, hook string, input *hookInput) error {
+	words, err := shlex.Split(hook)
+	if err != nil || len(words) == 0 {
+		return &CliError{
+			Err:  fmt.Errorf("invalid %s hook '%s'", input.Stage, hook),
+			Hint: "Hooks are command lines, e.g. ./changelog.sh --verbose",
+		}
+	}
+
+	stdin, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(words[0], words[1:]...) //nolint:gosec
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = extractMeta(ctx).stderr
+	cmd.Env = append(os.Environ(), "SCW_HOOK_STAGE="+input.Stage, "SCW_HOOK_COMMAND="+input.Command)
+
+	exitCode, err := ExecCmd(ctx, cmd)
+	if err != nil {
+		return fmt.Errorf("%s hook '%s' failed: %w", input.Stage, hook, err)
+	}
+	if exitCode != 0 {
+		return &CliError{
+			Err:  fmt.Errorf("%s hook '%s' failed with exit code %d", input.Stage, hook, exitCode),
+			Hint: "Hooks are defined in the hooks section of the CLI config",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package core_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+)
+
+type hookFlowerArgs struct {
+	Name string `json:"name"`
+}
+
+type hookFlower struct {
+	Name string `json:"name"`
+}
+
+func Test_Hooks(t *testing.T) {
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace: "test",
+			Resource:  "flower",
+			Verb:      "create",
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name: "name",
+				},
+			},
+			ArgsType: reflect.TypeOf(hookFlowerArgs{}),
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				return &hookFlower{Name: argsI.(*hookFlowerArgs).Name}, nil
+			},
+		},
+		&core.Command{
+			Namespace: "test",
+			Resource:  "flower",
+			Verb:      "delete",
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name:       "name",
+					Positional: true,
+				},
+			},
+			ArgsType: reflect.TypeOf(hookFlowerArgs{}),
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				return &core.SuccessResult{Resource: argsI.(*hookFlowerArgs).Name, Verb: "delete"}, nil
+			},
+		},
+	)
+
+	writeCliConfig := func(content string) core.BeforeFunc {
+		return func(ctx *core.BeforeFuncCtx) error {
+			configDir := filepath.Join(ctx.OverrideEnv["HOME"], ".config", "scw")
+			err := os.MkdirAll(configDir, 0o700)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(filepath.Join(configDir, "cli.yaml"), []byte(content), 0o600)
+		}
+	}
+	hooksConfig := writeCliConfig(`hooks:
+  "*.*.create":
+    post:
+      - changelog --verbose
+  test.flower.delete:
+    pre:
+      - check-delete
+  test.*:
+    pre:
+      - never-run
+`)
+
+	// Hooks print the input they receive, check-delete refuses to delete protected flowers.
+	overrideExec := func(_ *core.ExecFuncCtx, cmd *exec.Cmd) (int, error) {
+		input, err := io.ReadAll(cmd.Stdin)
+		if err != nil {
+			return 0, err
+		}
+		_, err = fmt.Fprintf(cmd.Stdout, "%s: %s\n", strings.Join(cmd.Args, " "), input)
+		if err != nil {
+			return 0, err
+		}
+		if cmd.Args[0] == "check-delete" && strings.Contains(string(input), "protected") {
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	t.Run("post hook", core.Test(&core.TestConfig{
+		Commands:     commands,
+		TmpHomeDir:   true,
+		BeforeFunc:   hooksConfig,
+		OverrideExec: overrideExec,
+		Cmd:          "scw test flower create name=rose",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("pre hook", core.Test(&core.TestConfig{
+		Commands:     commands,
+		TmpHomeDir:   true,
+		BeforeFunc:   hooksConfig,
+		OverrideExec: overrideExec,
+		Cmd:          "scw test flower delete rose",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("failing pre hook", core.Test(&core.TestConfig{
+		Commands:     commands,
+		TmpHomeDir:   true,
+		BeforeFunc:   hooksConfig,
+		OverrideExec: overrideExec,
+		Cmd:          "scw test flower delete protected-rose",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	t.Run("invalid glob", core.Test(&core.TestConfig{
+		Commands:     commands,
+		TmpHomeDir:   true,
+		BeforeFunc:   writeCliConfig("hooks:\n  \"test.[flower\":\n    pre:\n      - never-run\n"),
+		OverrideExec: overrideExec,
+		Cmd:          "scw test flower create name=rose",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+}
@@ -1,21 +1,248 @@
 package core
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
+	"syscall"
 	"time"
+
+	cliConfig "github.com/scaleway/scaleway-cli/v2/internal/config"
+)
+
+const (
+	defaultRetryMaxAttempts = 5
+	defaultRetryMaxDuration = 2 * time.Minute
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 30 * time.Second
+
+	retryMaxAttemptsEnv = "SCW_CLI_RETRY_MAX_ATTEMPTS"
+	retryMaxDurationEnv = "SCW_CLI_RETRY_MAX_DURATION"
+	retryBaseDelayEnv   = "SCW_CLI_RETRY_BASE_DELAY"
+	retryMaxDelayEnv    = "SCW_CLI_RETRY_MAX_DELAY"
 )
 
-const defaultRetryInterval = 1 * time.Second
+// retryPolicy defines when and how failed HTTP requests are retried.
+type retryPolicy struct {
+	// MaxAttempts is the number of times a request is sent, including the first one.
+	MaxAttempts int
+
+	// MaxDuration is the total time after which a request is no longer retried.
+	MaxDuration time.Duration
+
+	// BaseDelay is the delay before the first retry, it doubles for each following retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two retries.
+	MaxDelay time.Duration
+}
+
+func defaultRetryPolicy() *retryPolicy {
+	return &retryPolicy{
+		MaxAttempts: defaultRetryMaxAttempts,
+		MaxDuration: defaultRetryMaxDuration,
+		BaseDelay:   defaultRetryBaseDelay,
+		MaxDelay:    defaultRetryMaxDelay,
+	}
+}
+
+// loadRetryPolicy builds the retry policy from the retry section of the CLI config.
+// Environment variables have priority over the config.
+func loadRetryPolicy(ctx context.Context, config *cliConfig.RetryConfig) (*retryPolicy, error) {
+	policy := defaultRetryPolicy()
+	if config != nil {
+		if config.MaxAttempts != nil {
+			policy.MaxAttempts = *config.MaxAttempts
+		}
+		if config.MaxDuration != nil {
+			policy.MaxDuration = *config.MaxDuration
+		}
+		if config.BaseDelay != nil {
+			policy.BaseDelay = *config.BaseDelay
+		}
+		if config.MaxDelay != nil {
+			policy.MaxDelay = *config.MaxDelay
+		}
+	}
+
+	if value := ExtractEnv(ctx, retryMaxAttemptsEnv); value != "" {
+		maxAttempts, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, invalidRetryEnvError(retryMaxAttemptsEnv, value, "a number, e.g. 5")
+		}
+		policy.MaxAttempts = maxAttempts
+	}
 
+	durations := []struct {
+		env   string
+		value *time.Duration
+	}{
+		{retryMaxDurationEnv, &policy.MaxDuration},
+		{retryBaseDelayEnv, &policy.BaseDelay},
+		{retryMaxDelayEnv, &policy.MaxDelay},
+	}
+	for _, duration := range durations {
+		value := ExtractEnv(ctx, duration.env)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, invalidRetryEnvError(duration.env, value, "a duration, e.g. 30s")
+		}
+		*duration.value = parsed
+	}
+
+	if policy.MaxAttempts < 1 || policy.MaxDuration < 0 || policy.BaseDelay < 0 || policy.MaxDelay < 0 {
+		return nil, &CliError{
+			Err:  errors.New("invalid HTTP retry policy"),
+			Hint: "Retries require at least one attempt and positive durations, check the retry section of the CLI config and the SCW_CLI_RETRY_* environment variables",
+		}
+	}
+
+	return policy, nil
+}
+
+func invalidRetryEnvError(env string, value string, expected string) error {
+	return &CliError{
+		Err:  fmt.Errorf("invalid value '%s' for %s", value, env),
+		Hint: fmt.Sprintf("%s must be %s", env, expected),
+	}
+}
+
+// retryableHTTPTransport retries requests that failed because of a transient error.
+//
+// Requests rejected with 429 Too Many Requests are always retried as they were not processed.
+// Idempotent requests are also retried on 502, 503 and 504 responses and on connection resets.
+// The delay between two attempts grows exponentially with jitter, unless the API gives one in a Retry-After header.
 type retryableHTTPTransport struct {
 	transport http.RoundTripper
+
+	// policy can be changed once the CLI config is loaded, it is the default policy if nil.
+	policy *retryPolicy
+	logger *Logger
 }
 
 func (r *retryableHTTPTransport) RoundTrip(request *http.Request) (*http.Response, error) {
-	res, err := r.transport.RoundTrip(request)
-	if err == nil && res.StatusCode == http.StatusTooManyRequests {
-		time.Sleep(defaultRetryInterval)
-		return r.RoundTrip(request)
+	policy := r.policy
+	if policy == nil {
+		policy = defaultRetryPolicy()
+	}
+	deadline := time.Now().Add(policy.MaxDuration)
+
+	for attempt := 1; ; attempt++ {
+		res, err := r.transport.RoundTrip(request)
+
+		reason, retryable := retryReason(request, res, err)
+		if !retryable || attempt >= policy.MaxAttempts || !canReplayBody(request) {
+			return res, err
+		}
+
+		delay := policy.delay(attempt, res)
+		if time.Now().Add(delay).After(deadline) {
+			return res, err
+		}
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+			_ = res.Body.Close()
+		}
+		if r.logger != nil {
+			r.logger.Debugf("retrying %s %s in %s after %s (attempt %d/%d)\n", request.Method, request.URL.Redacted(), delay, reason, attempt+1, policy.MaxAttempts)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+
+		if request.Body != nil && request.Body != http.NoBody {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request = request.Clone(request.Context())
+			request.Body = body
+		}
+	}
+}
+
+// retryReason returns why a request must be retried, if it must.
+func retryReason(request *http.Request, res *http.Response, err error) (string, bool) {
+	if err != nil {
+		if isIdempotent(request) && isConnectionReset(err) {
+			return err.Error(), true
+		}
+		return "", false
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return res.Status, true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return res.Status, isIdempotent(request)
+	default:
+		return "", false
+	}
+}
+
+func isIdempotent(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// canReplayBody returns true if the request body can be sent again.
+func canReplayBody(request *http.Request) bool {
+	return request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+}
+
+// delay returns the time to wait before the next attempt.
+// The Retry-After header has priority over the exponential backoff.
+func (p *retryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return retryAfter
+		}
+	}
+
+	if p.BaseDelay <= 0 || p.MaxDelay <= 0 {
+		return 0
+	}
+
+	backoff := p.MaxDelay
+	if shift := attempt - 1; shift < 32 && p.BaseDelay<<shift > 0 && p.BaseDelay<<shift < p.MaxDelay {
+		backoff = p.BaseDelay << shift
+	}
+
+	// Half of the delay is random so that concurrent commands do not retry at the same time.
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
 	}
-	return res, err
+	return 0, false
 }
@@ -0,0 +1,83 @@
+package core_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func Test_HTTPRetry(t *testing.T) {
+	flowerCommand := func(verb string, method string) *core.Command {
+		return &core.Command{
+			Namespace:            "test",
+			Resource:             "flower",
+			Verb:                 verb,
+			ArgsType:             reflect.TypeOf(struct{}{}),
+			AllowAnonymousClient: true,
+			Run: func(ctx context.Context, _ interface{}) (interface{}, error) {
+				client, err := scw.NewClient(
+					scw.WithHTTPClient(core.ExtractHTTPClient(ctx)),
+					scw.WithoutAuth(),
+					scw.WithUserAgent("cli-e2e-test"),
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				request := &scw.ScalewayRequest{
+					Method: method,
+					Path:   "/test/v1/zones/fr-par-1/flowers/rose",
+				}
+				if method == http.MethodPost {
+					err = request.SetBody(map[string]string{"color": "red"})
+					if err != nil {
+						return nil, err
+					}
+				}
+
+				resp := &streamedFlower{}
+				err = client.Do(request, resp)
+				if err != nil {
+					return nil, err
+				}
+				return resp, nil
+			},
+		}
+	}
+
+	commands := core.NewCommands(
+		flowerCommand("get", http.MethodGet),
+		flowerCommand("update", http.MethodPost),
+	)
+
+	t.Run("retry-unavailable", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower get",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("retry-too-many-requests", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower update",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("no-retry-unavailable-post", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower update",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("invalid-env", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower get",
+		OverrideEnv: map[string]string{
+			"SCW_CLI_RETRY_MAX_ATTEMPTS": "twice",
+		},
+		Check: core.TestCheckGolden(),
+	}))
+}
@@ -0,0 +1,118 @@
+package human
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Format defines how tables and titles are rendered by Marshal.
+type Format int
+
+const (
+	// FormatText renders aligned columns for terminals.
+	FormatText = Format(iota)
+
+	// FormatMarkdown renders Markdown tables and headings.
+	FormatMarkdown
+
+	// FormatHTML renders HTML tables and headings.
+	FormatHTML
+)
+
+// ansiRegexp matches terminal styles that must not be written in documents.
+var ansiRegexp = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// formatTitle renders a title above the body of a resource or a section.
+func formatTitle(title string, body string, format Format) string {
+	switch format {
+	case FormatMarkdown:
+		return "### " + escapeMarkdown(title) + "\n\n" + body
+	case FormatHTML:
+		return "<h3>" + escapeHTML(title) + "</h3>\n" + body
+	default:
+		return getPreferences().style(title+":", color.Bold) + "\n" + body
+	}
+}
+
+// formatTable renders a grid where the first row is the header.
+func formatTable(grid [][]string, opt *MarshalOpt) (string, error) {
+	switch opt.Format {
+	case FormatMarkdown:
+		lines := []string(nil)
+		for i, row := range grid {
+			lines = append(lines, markdownRow(row))
+			if i == 0 {
+				separators := make([]string, len(row))
+				for j := range separators {
+					separators[j] = "---"
+				}
+				lines = append(lines, "| "+strings.Join(separators, " | ")+" |")
+			}
+		}
+		return strings.Join(lines, "\n"), nil
+	case FormatHTML:
+		lines := []string{"<table>", "<thead>", htmlRow(grid[0], "th"), "</thead>", "<tbody>"}
+		for _, row := range grid[1:] {
+			lines = append(lines, htmlRow(row, "td"))
+		}
+		lines = append(lines, "</tbody>", "</table>")
+		return strings.Join(lines, "\n"), nil
+	default:
+		return formatGrid(grid, !opt.DisableShrinking)
+	}
+}
+
+// formatKeyValues renders the fields of a resource, one field per row.
+func formatKeyValues(rows [][]string, format Format) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	switch format {
+	case FormatMarkdown:
+		lines := []string{"| Field | Value |", "| --- | --- |"}
+		for _, row := range rows {
+			lines = append(lines, markdownRow(row))
+		}
+		return strings.Join(lines, "\n")
+	default:
+		lines := []string{"<table>"}
+		for _, row := range rows {
+			lines = append(lines, "<tr><th>"+escapeHTML(row[0])+"</th><td>"+escapeHTML(row[1])+"</td></tr>")
+		}
+		lines = append(lines, "</table>")
+		return strings.Join(lines, "\n")
+	}
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		escaped = append(escaped, escapeMarkdown(cell))
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
+
+func htmlRow(cells []string, tag string) string {
+	row := strings.Builder{}
+	row.WriteString("<tr>")
+	for _, cell := range cells {
+		row.WriteString("<" + tag + ">" + escapeHTML(cell) + "</" + tag + ">")
+	}
+	row.WriteString("</tr>")
+	return row.String()
+}
+
+func escapeMarkdown(s string) string {
+	s = ansiRegexp.ReplaceAllString(s, "")
+	s = strings.NewReplacer("|", `\|`, "<", "&lt;", ">", "&gt;").Replace(s)
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+func escapeHTML(s string) string {
+	s = html.EscapeString(ansiRegexp.ReplaceAllString(s, ""))
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
@@ -35,7 +35,7 @@ func Marshal(data interface{}, opt *MarshalOpt) (string, error) {
 		subOpt := *opt
 		subOpt.Title = ""
 		body, err := Marshal(data, &subOpt)
-		return terminal.Style(opt.Title+":", color.Bold) + "\n" + body, err
+		return formatTitle(opt.Title, body, opt.Format), err
 	}
 
 	rValue := reflect.ValueOf(data)
@@ -65,12 +65,20 @@ func Marshal(data interface{}, opt *MarshalOpt) (string, error) {
 
 	// Handle errors
 	case rType.Implements(reflect.TypeOf((*error)(nil)).Elem()):
-		return terminal.Style(Capitalize(rValue.Interface().(error).Error()), color.FgRed), nil
+		return getPreferences().style(Capitalize(rValue.Interface().(error).Error()), color.FgRed), nil
 
 	// Handle stringers
 	case rType.Implements(reflect.TypeOf((*fmt.Stringer)(nil)).Elem()):
 		return rValue.Interface().(fmt.Stringer).String(), nil
 
+	// If data is a generic JSON object (e.g. the result of a query) uses marshalStruct
+	case !opt.TableCell && rType == reflect.TypeOf(map[string]interface{}{}):
+		return marshalStruct(rValue, opt)
+
+	// If data is a generic JSON list (e.g. the result of a query) uses marshalGenericSlice
+	case !opt.TableCell && rType == reflect.TypeOf([]interface{}{}):
+		return marshalGenericSlice(rValue, opt)
+
 	// If data is a pointer dereference an call Marshal again
 	case rType.Kind() == reflect.Ptr:
 		return Marshal(rValue.Elem().Interface(), opt)
@@ -204,6 +212,16 @@ func marshalStruct(value reflect.Value, opt *MarshalOpt) (string, error) {
 		return "", err
 	}
 
+	// Documents are rendered with a table for fields followed by a sub-table or a list for each section.
+	if opt.Format != FormatText {
+		blocks := []string(nil)
+		if fields := formatKeyValues(data, opt.Format); fields != "" {
+			blocks = append(blocks, fields)
+		}
+		blocks = append(blocks, sectionsStrs...)
+		return strings.Join(blocks, "\n\n"), nil
+	}
+
 	buffer := bytes.Buffer{}
 	w := tabwriter.NewWriter(&buffer, 5, 1, colPadding, ' ', tabwriter.ANSIGraphicsRendition)
 	for _, line := range data {
@@ -333,7 +351,86 @@ func marshalSlice(slice reflect.Value, opt *MarshalOpt) (string, error) {
 		}
 		grid = append(grid, row)
 	}
-	return formatGrid(grid, !opt.DisableShrinking)
+	return formatTable(grid, opt)
+}
+
+// marshalGenericSlice marshals a list of generic JSON values.
+// A list of objects is printed as a table using object keys as columns, other lists are printed with one item per line.
+func marshalGenericSlice(slice reflect.Value, opt *MarshalOpt) (string, error) {
+	items := slice.Interface().([]interface{})
+
+	objects := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		object, isObject := item.(map[string]interface{})
+		if !isObject {
+			break
+		}
+		objects = append(objects, object)
+	}
+
+	if len(objects) == 0 || len(objects) != len(items) {
+		strs := []string(nil)
+		for _, item := range items {
+			str, err := Marshal(item, &MarshalOpt{})
+			if err != nil {
+				return "", err
+			}
+			strs = append(strs, str)
+		}
+		return strings.Join(strs, "\n"), nil
+	}
+
+	// If there is no Field in opt we use all object keys
+	fields := opt.Fields
+	if len(fields) == 0 {
+		keys := map[string]bool{}
+		for _, object := range objects {
+			for key := range object {
+				keys[key] = true
+			}
+		}
+		for key := range keys {
+			fields = append(fields, &MarshalFieldOpt{FieldName: key})
+		}
+		sort.Slice(fields, func(i, j int) bool {
+			return fields[i].FieldName < fields[j].FieldName
+		})
+	}
+
+	subOpts := &MarshalOpt{TableCell: true}
+
+	grid := make([][]string, 0, len(objects)+1)
+	headerRow := []string(nil)
+	for _, fieldSpec := range fields {
+		headerRow = append(headerRow, fieldSpec.getLabel())
+	}
+	grid = append(grid, headerRow)
+
+	for _, object := range objects {
+		row := []string(nil)
+		for _, fieldSpec := range fields {
+			v, err := gofields.GetValue(object, fieldSpec.FieldName)
+			if err != nil || v == nil {
+				row = append(row, "-")
+				continue
+			}
+
+			var str string
+			fieldValue := reflect.ValueOf(v)
+			switch {
+			case fieldValue.Type().Kind() == reflect.Slice:
+				str, err = marshalInlineSlice(fieldValue)
+			default:
+				str, err = Marshal(v, subOpts)
+			}
+			if err != nil {
+				return "", err
+			}
+			row = append(row, str)
+		}
+		grid = append(grid, row)
+	}
+	return formatTable(grid, opt)
 }
 
 // marshalInlineSlice transforms nested scalar slices in an inline string representation
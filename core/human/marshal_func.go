@@ -9,10 +9,8 @@ import (
 	"sync"
 	"time"
 
-	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/hashicorp/go-version"
-	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
@@ -31,12 +29,12 @@ func init() {
 	marshalerFuncs.Store(reflect.TypeOf(bool(false)), func(i interface{}, _ *MarshalOpt) (string, error) {
 		v := i.(bool)
 		if v {
-			return terminal.Style("true", color.FgGreen), nil
+			return getPreferences().style("true", color.FgGreen), nil
 		}
-		return terminal.Style("false", color.FgRed), nil
+		return getPreferences().style("false", color.FgRed), nil
 	})
 	marshalerFuncs.Store(reflect.TypeOf(time.Time{}), func(i interface{}, _ *MarshalOpt) (string, error) {
-		return humanize.Time(i.(time.Time)), nil
+		return getPreferences().formatTime(i.(time.Time)), nil
 	})
 	marshalerFuncs.Store(reflect.TypeOf(&time.Time{}), func(i interface{}, _ *MarshalOpt) (string, error) {
 		t := i.(*time.Time)
@@ -46,22 +44,10 @@ func init() {
 		return Marshal(*t, nil)
 	})
 	marshalerFuncs.Store(reflect.TypeOf(scw.Size(0)), func(i interface{}, _ *MarshalOpt) (string, error) {
-		size := uint64(i.(scw.Size))
-
-		if isIECNotation := size%1024 == 0 && size%1000 != 0; isIECNotation {
-			return humanize.IBytes(size), nil
-		}
-
-		return humanize.Bytes(size), nil
+		return getPreferences().formatSize(uint64(i.(scw.Size))), nil
 	})
 	marshalerFuncs.Store(reflect.TypeOf(scw.SizePtr(0)), func(i interface{}, _ *MarshalOpt) (string, error) {
-		size := uint64(*i.(*scw.Size))
-
-		if isIECNotation := size%1024 == 0 && size%1000 != 0; isIECNotation {
-			return humanize.IBytes(size), nil
-		}
-
-		return humanize.Bytes(size), nil
+		return getPreferences().formatSize(uint64(*i.(*scw.Size))), nil
 	})
 	marshalerFuncs.Store(reflect.TypeOf([]scw.Size{}), func(i interface{}, _ *MarshalOpt) (string, error) {
 		sizes := i.([]scw.Size)
@@ -217,7 +203,7 @@ func EnumMarshalFunc(specs EnumMarshalSpecs) MarshalerFunc {
 			if spec.Value != "" {
 				value = spec.Value
 			}
-			value = terminal.Style(value, spec.Attribute)
+			value = getPreferences().style(value, spec.Attribute)
 		}
 		return value, nil
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/alecthomas/assert"
 	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
 	"github.com/scaleway/scaleway-cli/v2/core/human"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
@@ -309,6 +310,76 @@ func TestMarshal(t *testing.T) {
 	}))
 }
 
+func TestMarshalPreferences(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	t.Cleanup(func() {
+		color.NoColor = noColor
+		human.SetPreferences(nil)
+	})
+
+	date := time.Date(1990, 11, 17, 20, 20, 0, 0, time.UTC)
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.NoError(t, err)
+
+	stateMarshalFunc := human.EnumMarshalFunc(human.EnumMarshalSpecs{
+		"running": &human.EnumMarshalSpec{Attribute: color.FgGreen},
+	})
+
+	t.Run("default", func(t *testing.T) {
+		human.SetPreferences(nil)
+		assertMarshal(t, date, humanize.Time(date))
+		assertMarshal(t, scw.Size(20*1000*1000*1000), "20 GB")
+		assertMarshal(t, scw.Size(20*1024*1024*1024), "20 GiB")
+		state, err := stateMarshalFunc("running", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "\x1b[32mrunning\x1b[0m", state)
+	})
+
+	t.Run("absolute time", func(t *testing.T) {
+		human.SetPreferences(&human.Preferences{AbsoluteTime: true, Location: time.UTC})
+		assertMarshal(t, date, "1990-11-17T20:20:00Z")
+		assertMarshal(t, &date, "1990-11-17T20:20:00Z")
+	})
+
+	t.Run("timezone", func(t *testing.T) {
+		human.SetPreferences(&human.Preferences{AbsoluteTime: true, Location: paris})
+		assertMarshal(t, date, "1990-11-17T21:20:00+01:00")
+	})
+
+	t.Run("si units", func(t *testing.T) {
+		human.SetPreferences(&human.Preferences{SizeUnits: human.SizeUnitsSI})
+		assertMarshal(t, scw.Size(20*1024*1024*1024), "22 GB")
+	})
+
+	t.Run("iec units", func(t *testing.T) {
+		human.SetPreferences(&human.Preferences{SizeUnits: human.SizeUnitsIEC})
+		assertMarshal(t, scw.SizePtr(20*1000*1000*1000), "19 GiB")
+	})
+
+	t.Run("theme", func(t *testing.T) {
+		human.SetPreferences(&human.Preferences{Theme: human.Themes["colorblind"]})
+		state, err := stateMarshalFunc("running", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "\x1b[34mrunning\x1b[0m", state)
+	})
+
+	t.Run("disabled colors", func(t *testing.T) {
+		human.SetPreferences(&human.Preferences{DisableColors: true})
+		state, err := stateMarshalFunc("running", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "running", state)
+		assertMarshal(t, true, "true")
+	})
+}
+
+func assertMarshal(t *testing.T, data interface{}, expected string) {
+	t.Helper()
+	result, err := human.Marshal(data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
 func Test_getStructFieldsIndex(t *testing.T) {
 	type args struct {
 		v reflect.Type
@@ -0,0 +1,128 @@
+package human
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
+)
+
+// Theme maps the colors used by marshalers to the colors printed in the terminal.
+// Colors missing from the theme are printed as is.
+type Theme map[color.Attribute]color.Attribute
+
+// Themes lists the color themes that can be selected in the CLI config.
+var Themes = map[string]Theme{
+	// default keeps the colors defined by commands.
+	"default": {},
+
+	// bright uses high intensity colors that are easier to read on dark terminals.
+	"bright": {
+		color.FgRed:     color.FgHiRed,
+		color.FgGreen:   color.FgHiGreen,
+		color.FgYellow:  color.FgHiYellow,
+		color.FgBlue:    color.FgHiBlue,
+		color.FgMagenta: color.FgHiMagenta,
+		color.FgCyan:    color.FgHiCyan,
+	},
+
+	// colorblind avoids telling states apart with red and green only.
+	"colorblind": {
+		color.FgRed:   color.FgMagenta,
+		color.FgGreen: color.FgBlue,
+	},
+}
+
+// SizeUnits defines how sizes are printed.
+type SizeUnits string
+
+const (
+	// SizeUnitsAuto prints sizes multiple of 1024 with IEC units (e.g. GiB) and other sizes with SI units (e.g. GB).
+	SizeUnitsAuto = SizeUnits("auto")
+
+	// SizeUnitsSI prints sizes with SI units, e.g. 20 GB.
+	SizeUnitsSI = SizeUnits("si")
+
+	// SizeUnitsIEC prints sizes with IEC units, e.g. 20 GiB.
+	SizeUnitsIEC = SizeUnits("iec")
+)
+
+// Preferences customize how values are rendered by Marshal.
+type Preferences struct {
+	// Theme is applied on colors, it can be nil to keep the default colors.
+	Theme Theme
+
+	// DisableColors prints values without any color.
+	DisableColors bool
+
+	// AbsoluteTime prints dates instead of the time elapsed since them, e.g. 3 days ago.
+	AbsoluteTime bool
+
+	// Location is the timezone used to print absolute dates, default is the local timezone.
+	Location *time.Location
+
+	// SizeUnits defines the units used to print sizes, default is SizeUnitsAuto.
+	SizeUnits SizeUnits
+}
+
+var (
+	preferences   = &Preferences{}
+	preferencesMu sync.RWMutex
+)
+
+// SetPreferences sets the preferences used by Marshal.
+// It returns the previous preferences so that they can be restored once values are marshaled.
+func SetPreferences(p *Preferences) *Preferences {
+	preferencesMu.Lock()
+	defer preferencesMu.Unlock()
+	if p == nil {
+		p = &Preferences{}
+	}
+	previous := preferences
+	preferences = p
+	return previous
+}
+
+func getPreferences() *Preferences {
+	preferencesMu.RLock()
+	defer preferencesMu.RUnlock()
+	return preferences
+}
+
+// style colors a value using the color theme.
+func (p *Preferences) style(value string, attribute color.Attribute) string {
+	if p.DisableColors {
+		return value
+	}
+	if themed, exists := p.Theme[attribute]; exists {
+		attribute = themed
+	}
+	return terminal.Style(value, attribute)
+}
+
+func (p *Preferences) formatTime(t time.Time) string {
+	if !p.AbsoluteTime {
+		return humanize.Time(t)
+	}
+	location := p.Location
+	if location == nil {
+		location = time.Local
+	}
+	return t.In(location).Format(time.RFC3339)
+}
+
+func (p *Preferences) formatSize(size uint64) string {
+	switch p.SizeUnits {
+	case SizeUnitsSI:
+		return humanize.Bytes(size)
+	case SizeUnitsIEC:
+		return humanize.IBytes(size)
+	default:
+		if isIECNotation := size%1024 == 0 && size%1000 != 0; isIECNotation {
+			return humanize.IBytes(size)
+		}
+		return humanize.Bytes(size)
+	}
+}
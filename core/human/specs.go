@@ -18,15 +18,20 @@ type MarshalOpt struct {
 
 	// DisableShrinking will disable columns shrinking based on terminal size
 	DisableShrinking bool
+
+	// Format defines how tables and titles are rendered, default is text for terminals
+	Format Format
 }
 
 func (m *MarshalOpt) subOption(section string) *MarshalOpt {
-	subOpt, exists := m.SubOptions[section]
-	if exists {
-		return subOpt
+	subOpt := &MarshalOpt{}
+	if opt, exists := m.SubOptions[section]; exists {
+		copied := *opt
+		subOpt = &copied
 	}
+	subOpt.Format = m.Format
 
-	return &MarshalOpt{}
+	return subOpt
 }
 
 type MarshalFieldOpt struct {
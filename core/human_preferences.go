@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/core/human"
+	cliConfig "github.com/scaleway/scaleway-cli/v2/internal/config"
+)
+
+const humanThemeNone = "none"
+
+// applyHumanPreferences sets the preferences of the human marshaler for the duration of a Bootstrap.
+// Colors are also disabled outside the human marshaler, e.g. in interactive prompts.
+// It returns a function restoring the previous preferences and colors, so that they do not leak to the next Bootstrap.
+func applyHumanPreferences(ctx context.Context) func() {
+	preferences := extractMeta(ctx).humanPreferences
+	noColor := color.NoColor
+	previous := human.SetPreferences(preferences)
+	if preferences.DisableColors {
+		color.NoColor = true
+	}
+	return func() {
+		human.SetPreferences(previous)
+		color.NoColor = noColor
+	}
+}
+
+// humanPreferences converts the human section of the CLI config to the preferences used by the human marshaler.
+func humanPreferences(config *cliConfig.HumanConfig) (*human.Preferences, error) {
+	preferences := &human.Preferences{}
+	if config == nil {
+		return preferences, nil
+	}
+
+	switch config.Theme {
+	case "":
+	case humanThemeNone:
+		preferences.DisableColors = true
+	default:
+		theme, exists := human.Themes[config.Theme]
+		if !exists {
+			themes := []string{humanThemeNone}
+			for name := range human.Themes {
+				themes = append(themes, name)
+			}
+			sort.Strings(themes)
+			return nil, invalidHumanConfigError("theme", config.Theme, themes)
+		}
+		preferences.Theme = theme
+	}
+
+	switch config.Timestamps {
+	case "", "relative":
+	case "absolute":
+		preferences.AbsoluteTime = true
+	default:
+		return nil, invalidHumanConfigError("timestamps", config.Timestamps, []string{"absolute", "relative"})
+	}
+
+	if config.Timezone != "" {
+		location, err := time.LoadLocation(config.Timezone)
+		if err != nil {
+			return nil, &CliError{
+				Err:  fmt.Errorf("invalid timezone '%s' in the human section of the CLI config: %w", config.Timezone, err),
+				Hint: "Use a timezone from the IANA database, e.g. Europe/Paris, UTC or Local",
+			}
+		}
+		preferences.Location = location
+	}
+
+	switch sizeUnits := human.SizeUnits(config.SizeUnits); sizeUnits {
+	case "":
+	case human.SizeUnitsAuto, human.SizeUnitsSI, human.SizeUnitsIEC:
+		preferences.SizeUnits = sizeUnits
+	default:
+		return nil, invalidHumanConfigError("size_units", config.SizeUnits, []string{
+			string(human.SizeUnitsAuto),
+			string(human.SizeUnitsIEC),
+			string(human.SizeUnitsSI),
+		})
+	}
+
+	return preferences, nil
+}
+
+func invalidHumanConfigError(key string, value string, validValues []string) error {
+	return &CliError{
+		Err:  fmt.Errorf("invalid %s '%s' in the human section of the CLI config", key, value),
+		Hint: "Valid values are: " + strings.Join(validValues, ", "),
+	}
+}
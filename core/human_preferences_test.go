@@ -0,0 +1,80 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-cli/v2/core/human"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HumanPreferences(t *testing.T) {
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace: "get",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return "ok", nil
+			},
+		},
+	)
+
+	writeCliConfig := func(content string) core.BeforeFunc {
+		return func(ctx *core.BeforeFuncCtx) error {
+			configDir := filepath.Join(ctx.OverrideEnv["HOME"], ".config", "scw")
+			err := os.MkdirAll(configDir, 0o700)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(filepath.Join(configDir, "cli.yaml"), []byte(content), 0o600)
+		}
+	}
+
+	t.Run("valid", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: writeCliConfig("human:\n  theme: none\n  timestamps: absolute\n  timezone: Europe/Paris\n  size_units: iec\n"),
+		Cmd:        "scw get",
+		Check:      core.TestCheckGolden(),
+	}))
+
+	t.Run("invalid-theme", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: writeCliConfig("human:\n  theme: rainbow\n"),
+		Cmd:        "scw get",
+		Check:      core.TestCheckGolden(),
+	}))
+
+	t.Run("invalid-timezone", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: writeCliConfig("human:\n  timezone: Mars/Olympus\n"),
+		Cmd:        "scw get",
+		Check:      core.TestCheckGolden(),
+	}))
+
+	// Preferences only apply to the Bootstrap reading the CLI config.
+	noColor := color.NoColor
+	t.Run("restored", core.Test(&core.TestConfig{
+		Commands:        commands,
+		TmpHomeDir:      true,
+		DisableParallel: true,
+		BeforeFunc:      writeCliConfig("human:\n  theme: none\n  size_units: si\n"),
+		Cmd:             "scw get",
+		Check: func(t *testing.T, _ *core.CheckFuncCtx) {
+			t.Helper()
+			assert.Equal(t, noColor, color.NoColor)
+			str, err := human.Marshal(scw.Size(1<<30), nil)
+			require.NoError(t, err)
+			assert.Equal(t, "1.0 GiB", str)
+		},
+	}))
+}
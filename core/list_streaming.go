@@ -0,0 +1,191 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// listStreamingTransport prints the items of a paginated list as soon as each page is fetched.
+//
+// List commands fetch all pages before returning their result, which can take a while for large lists.
+// While listStreamingInterceptor runs a list command, the pages of the list are given to onPage as they are received.
+// Responses are returned unchanged so that the SDK still fetches all pages and the command returns the whole list.
+type listStreamingTransport struct {
+	transport http.RoundTripper
+
+	// printer is used to print list pages, streaming is disabled if it is nil.
+	printer *Printer
+
+	mu sync.Mutex
+	// enabled is true while a list command runs.
+	enabled bool
+	// listPath is the path of the list, it is the first paginated request of the command.
+	listPath string
+	// err is the error returned when printing a page, the next pages are not printed.
+	err error
+	// streamed is true once a page was printed, the command result must not be printed again.
+	streamed bool
+}
+
+func newListStreamingTransport(transport http.RoundTripper) *listStreamingTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &listStreamingTransport{
+		transport: transport,
+	}
+}
+
+// start prints the pages of the next list fetched.
+func (t *listStreamingTransport) start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.enabled = true
+	t.listPath = ""
+	t.err = nil
+}
+
+// stop sends the requests as usual and returns the error of the pages that could not be printed.
+func (t *listStreamingTransport) stop() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.enabled = false
+	return t.err
+}
+
+// isStreamed returns true if the result of the command was printed page by page.
+func (t *listStreamingTransport) isStreamed() bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.streamed
+}
+
+func (t *listStreamingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.transport.RoundTrip(request)
+	if err != nil || response.StatusCode != http.StatusOK || !t.isListRequest(request) {
+		return response, err
+	}
+
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	items, isList := listPageItems(body)
+	if isList {
+		t.onPage(items)
+	}
+	return response, nil
+}
+
+// isListRequest returns true if the request fetches a page of the list of the running command.
+func (t *listStreamingTransport) isListRequest(request *http.Request) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.enabled || t.err != nil || request.Method != http.MethodGet || request.URL.Query().Get("page") == "" {
+		return false
+	}
+	if t.listPath == "" {
+		t.listPath = request.URL.Path
+	}
+	return t.listPath == request.URL.Path
+}
+
+// onPage prints the items of a page of the list.
+func (t *listStreamingTransport) onPage(items []json.RawMessage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.streamed = true
+	t.err = t.printer.printListPage(items)
+}
+
+// listPageItems returns the items of a page, e.g. the servers of {"servers": [{...}, {...}], "total_count": 3}.
+// A page must contain a single list.
+func listPageItems(body []byte) ([]json.RawMessage, bool) {
+	page := map[string]json.RawMessage{}
+	err := json.Unmarshal(body, &page)
+	if err != nil {
+		return nil, false
+	}
+
+	items := []json.RawMessage(nil)
+	lists := 0
+	for _, value := range page {
+		if !bytes.HasPrefix(bytes.TrimSpace(value), []byte("[")) {
+			continue
+		}
+		lists++
+		err := json.Unmarshal(value, &items)
+		if err != nil {
+			return nil, false
+		}
+	}
+	return items, lists == 1
+}
+
+// listStreamingInterceptor prints the pages of the list fetched by list commands as they are received,
+// see listStreamingTransport. The command runs once and its whole result is returned, e.g. to post hooks.
+// Only the list commands whose result is the list they fetch are streamed: commands with an interceptor
+// or whose run was overridden may change the items of the list.
+// Lists of all localities, e.g. zone=all, are not streamed as their items are sorted by locality.
+func listStreamingInterceptor(ctx context.Context, argsI interface{}, runner CommandRunner) (interface{}, error) {
+	meta := extractMeta(ctx)
+	transport := meta.listStreaming
+	if transport == nil || transport.printer == nil || meta.command == nil || !meta.command.streamsList() || listsAllLocalities(argsI) {
+		return runner(ctx, argsI)
+	}
+
+	// Clients provided in the bootstrap config, e.g. in tests, do not send their requests through the transport.
+	if meta.isClientFromBootstrapConfig {
+		client, err := copyClient(meta.Client, meta.httpClient, meta.BuildInfo)
+		if err != nil {
+			return nil, err
+		}
+		bootstrapClient := meta.Client
+		meta.Client = client
+		defer func() { meta.Client = bootstrapClient }()
+	}
+
+	transport.start()
+	result, err := runner(ctx, argsI)
+	printErr := transport.stop()
+	if err != nil {
+		return nil, err
+	}
+	if printErr != nil {
+		return nil, printErr
+	}
+	return result, nil
+}
+
+// streamsList returns true if the command is a list command whose result is the list it fetches.
+func (c *Command) streamsList() bool {
+	return c.Verb == "list" && c.Interceptor == nil && !c.runOverridden
+}
+
+// listsAllLocalities returns true if the zone or region argument is all.
+func listsAllLocalities(argsI interface{}) bool {
+	argsValue := reflect.Indirect(reflect.ValueOf(argsI))
+	if argsValue.Kind() != reflect.Struct {
+		return false
+	}
+	for _, name := range []string{"Zone", "Region"} {
+		field := argsValue.FieldByName(name)
+		if field.IsValid() && field.Kind() == reflect.String && field.String() == AllLocalities {
+			return true
+		}
+	}
+	return false
+}
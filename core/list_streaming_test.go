@@ -0,0 +1,194 @@
+package core_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+type streamedFlower struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type listStreamedFlowersResponse struct {
+	Flowers    []*streamedFlower `json:"flowers"`
+	TotalCount uint32            `json:"total_count"`
+}
+
+func (r *listStreamedFlowersResponse) UnsafeGetTotalCount() uint32 {
+	return r.TotalCount
+}
+
+func (r *listStreamedFlowersResponse) UnsafeAppend(res interface{}) (uint32, error) {
+	results, ok := res.(*listStreamedFlowersResponse)
+	if !ok {
+		return 0, fmt.Errorf("%T type cannot be appended to type %T", res, r)
+	}
+
+	r.Flowers = append(r.Flowers, results.Flowers...)
+	r.TotalCount += uint32(len(results.Flowers))
+	return uint32(len(results.Flowers)), nil
+}
+
+// List commands print each page of their list as soon as it is fetched, their whole result is still returned.
+// Commands with an interceptor or an overridden run are not streamed as they may change the items of the list.
+func Test_ListStreaming(t *testing.T) {
+	runs := 0
+	run := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		runs++
+		client, err := scw.NewClient(
+			scw.WithHTTPClient(core.ExtractHTTPClient(ctx)),
+			scw.WithoutAuth(),
+			scw.WithUserAgent("cli-e2e-test"),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &listStreamedFlowersResponse{}
+		err = client.Do(&scw.ScalewayRequest{
+			Method: http.MethodGet,
+			Path:   "/test/v1/zones/fr-par-1/flowers",
+			Query:  url.Values{},
+		}, resp, scw.WithAllPages())
+		if err != nil {
+			return nil, err
+		}
+		return resp.Flowers, nil
+	}
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "flower",
+			Verb:                 "list",
+			ArgsType:             reflect.TypeOf(struct{}{}),
+			AllowAnonymousClient: true,
+			Run:                  run,
+		},
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "colored-flower",
+			Verb:                 "list",
+			ArgsType:             reflect.TypeOf(struct{}{}),
+			AllowAnonymousClient: true,
+			Run:                  run,
+			Interceptor: func(ctx context.Context, argsI interface{}, runner core.CommandRunner) (interface{}, error) {
+				result, err := runner(ctx, argsI)
+				if err != nil {
+					return nil, err
+				}
+				for _, flower := range result.([]*streamedFlower) {
+					flower.Color = strings.ToUpper(flower.Color)
+				}
+				return result, nil
+			},
+		},
+	)
+	renamedFlowerList := &core.Command{
+		Namespace:            "test",
+		Resource:             "renamed-flower",
+		Verb:                 "list",
+		ArgsType:             reflect.TypeOf(struct{}{}),
+		AllowAnonymousClient: true,
+		Run:                  run,
+	}
+	renamedFlowerList.Override(func(c *core.Command) *core.Command {
+		c.Run = func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			result, err := run(ctx, argsI)
+			if err != nil {
+				return nil, err
+			}
+			for _, flower := range result.([]*streamedFlower) {
+				flower.Name += "-renamed"
+			}
+			return result, nil
+		}
+		return c
+	})
+	commands.Add(renamedFlowerList)
+
+	t.Run("ndjson", core.Test(&core.TestConfig{
+		Commands:   commands,
+		BeforeFunc: resetRuns(&runs),
+		Cmd:        "scw test flower list -o ndjson",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			checkRuns(&runs, 1),
+			checkFlowers(3),
+		),
+	}))
+
+	t.Run("ndjson-single-page", core.Test(&core.TestConfig{
+		Commands:   commands,
+		BeforeFunc: resetRuns(&runs),
+		Cmd:        "scw test flower list -o ndjson",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			checkRuns(&runs, 1),
+			checkFlowers(1),
+		),
+	}))
+
+	t.Run("ndjson-interceptor", core.Test(&core.TestConfig{
+		Commands:   commands,
+		BeforeFunc: resetRuns(&runs),
+		Cmd:        "scw test colored-flower list -o ndjson",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			checkRuns(&runs, 1),
+			checkFlowers(3),
+		),
+	}))
+
+	t.Run("ndjson-overridden-run", core.Test(&core.TestConfig{
+		Commands:   commands,
+		BeforeFunc: resetRuns(&runs),
+		Cmd:        "scw test renamed-flower list -o ndjson",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			checkRuns(&runs, 1),
+			checkFlowers(3),
+		),
+	}))
+
+	t.Run("human", core.Test(&core.TestConfig{
+		Commands:   commands,
+		BeforeFunc: resetRuns(&runs),
+		Cmd:        "scw test flower list",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			checkRuns(&runs, 1),
+			checkFlowers(3),
+		),
+	}))
+}
+
+func resetRuns(runs *int) core.BeforeFunc {
+	return func(_ *core.BeforeFuncCtx) error {
+		*runs = 0
+		return nil
+	}
+}
+
+func checkRuns(runs *int, expected int) core.TestCheck {
+	return func(t *testing.T, _ *core.CheckFuncCtx) {
+		t.Helper()
+		assert.Equal(t, expected, *runs)
+	}
+}
+
+func checkFlowers(expected int) core.TestCheck {
+	return func(t *testing.T, ctx *core.CheckFuncCtx) {
+		t.Helper()
+		assert.Len(t, ctx.Result, expected)
+	}
+}
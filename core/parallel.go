@@ -0,0 +1,79 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/spf13/cobra"
+)
+
+// runPositionalArgs runs the command once for each positional argument, at most parallel runs at a time.
+// Results are returned in the order of the positional arguments.
+// Unlike sequential runs, a failing run does not stop the others: all errors are returned together with the results
+// of the runs that succeeded.
+func runPositionalArgs(ctx context.Context, cobraCmd *cobra.Command, cmd *Command, rawArgs args.RawArgs, argName string, positionalArgs []string, parallel int) (MultiResults, error) {
+	meta := extractMeta(ctx)
+	results := make(MultiResults, len(positionalArgs))
+	errs := make([]error, len(positionalArgs))
+
+	semaphore := make(chan struct{}, parallel)
+	wg := sync.WaitGroup{}
+	for i, positionalArg := range positionalArgs {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, positionalArg string) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			// rawArgs is clipped so that concurrent runs never append to the same array.
+			rawArgsWithPositional := slices.Clip(rawArgs).Add(argName, positionalArg)
+			result, err := run(ctx, cobraCmd, cmd, rawArgsWithPositional)
+			if err == nil {
+				result, err = meta.resultListOptions.apply(result)
+			}
+			results[i], errs[i] = result, err
+		}(i, positionalArg)
+	}
+	wg.Wait()
+
+	err := parallelRunsError(positionalArgs, errs)
+	if err != nil {
+		succeeded := MultiResults(nil)
+		for i, result := range results {
+			if errs[i] == nil {
+				succeeded = append(succeeded, result)
+			}
+		}
+		return succeeded, err
+	}
+	return results, nil
+}
+
+// parallelRunsError returns an error listing the positional arguments whose run failed, or nil if all runs succeeded.
+func parallelRunsError(positionalArgs []string, errs []error) error {
+	failures := []string(nil)
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", positionalArgs[i], err))
+		}
+	}
+
+	switch {
+	case len(failures) == 0:
+		return nil
+	case len(positionalArgs) == 1:
+		return errs[0]
+	}
+
+	return &CliError{
+		Err:     fmt.Errorf("%d of %d commands failed", len(failures), len(positionalArgs)),
+		Details: strings.Join(failures, "\n"),
+		Hint:    "Commands with other arguments succeeded, run again with the failed arguments only",
+	}
+}
@@ -0,0 +1,103 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/stretchr/testify/assert"
+)
+
+type parallelFlowerArgs struct {
+	Name string
+}
+
+type parallelFlower struct {
+	Name string
+}
+
+func Test_Parallel(t *testing.T) {
+	running := int32(0)
+	maxRunning := int32(0)
+
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace: "test",
+			Resource:  "flower",
+			Verb:      "get",
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name:       "name",
+					Positional: true,
+				},
+			},
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(parallelFlowerArgs{}),
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				name := argsI.(*parallelFlowerArgs).Name
+
+				current := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					previousMax := atomic.LoadInt32(&maxRunning)
+					if current <= previousMax || atomic.CompareAndSwapInt32(&maxRunning, previousMax, current) {
+						break
+					}
+				}
+
+				// The first flowers are the slowest so that runs do not end in the order they started.
+				time.Sleep(time.Duration(10-len(name)) * 10 * time.Millisecond)
+
+				if strings.HasPrefix(name, "wilted") {
+					return nil, fmt.Errorf("flower %s not found", name)
+				}
+				return &parallelFlower{Name: name}, nil
+			},
+		},
+	)
+
+	t.Run("ordered results", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower get rose tulip lily daisy iris --parallel 2 -o json",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+			func(t *testing.T, _ *core.CheckFuncCtx) {
+				t.Helper()
+				assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(2))
+			},
+		),
+	}))
+
+	t.Run("aggregated errors", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower get wilted-rose tulip wilted-lily daisy --parallel 3",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+			core.TestCheckError(&core.CliError{
+				Err:     errors.New("2 of 4 commands failed"),
+				Details: "wilted-rose: flower wilted-rose not found\nwilted-lily: flower wilted-lily not found",
+				Hint:    "Commands with other arguments succeeded, run again with the failed arguments only",
+			}),
+		),
+	}))
+
+	t.Run("invalid", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower get rose --parallel 0",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckError(&core.CliError{
+				Err:  errors.New("invalid value '0' for --parallel"),
+				Hint: "--parallel must be at least 1",
+			}),
+		),
+	}))
+}
@@ -0,0 +1,346 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// pluginPrefix is the prefix of plugin executables, e.g. scw-ourteam provides the ourteam namespace.
+	pluginPrefix = "scw-"
+
+	// pluginManifestArg is the argument given to a plugin to get its manifest.
+	pluginManifestArg = "--scw-plugin-manifest"
+
+	pluginManifestTimeout  = 5 * time.Second
+	pluginManifestCacheTTL = 30 * 24 * time.Hour
+	pluginCacheNamespace   = "plugins"
+	pluginGroup            = "plugin"
+)
+
+var pluginNameRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// pluginManifest describes the commands of a plugin so that they are listed in usages and autocompleted.
+// A plugin prints it in JSON when it is run with pluginManifestArg.
+type pluginManifest struct {
+	Short    string                   `json:"short"`
+	Long     string                   `json:"long"`
+	Commands []*pluginManifestCommand `json:"commands"`
+}
+
+type pluginManifestCommand struct {
+	// Path is the command without the namespace, e.g. "server list".
+	Path  string   `json:"path"`
+	Short string   `json:"short"`
+	Args  []string `json:"args"`
+}
+
+// findPlugins returns the path of the plugin executables found in PATH, by plugin name.
+// When several executables have the same name, the first one in PATH is used.
+func findPlugins(ctx context.Context) map[string]string {
+	plugins := map[string]string{}
+	if runtime.GOOS == "js" {
+		return plugins
+	}
+
+	for _, dir := range filepath.SplitList(ExtractEnv(ctx, "PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, isPlugin := strings.CutPrefix(entry.Name(), pluginPrefix)
+			if runtime.GOOS == "windows" {
+				name, isPlugin = strings.CutSuffix(name, ".exe")
+			}
+			if !isPlugin || !pluginNameRegex.MatchString(name) {
+				continue
+			}
+			if _, exists := plugins[name]; exists || !isPluginExecutable(filepath.Join(dir, entry.Name())) {
+				continue
+			}
+			plugins[name] = filepath.Join(dir, entry.Name())
+		}
+	}
+
+	return plugins
+}
+
+// registerPlugins adds a namespace for each plugin found in PATH, it reads every directory of PATH.
+// It is only used when all namespaces are listed, e.g. by the root usage or the completion of namespaces,
+// other commands look for a single plugin with registerPlugin.
+// Plugins cannot replace the namespaces of the CLI.
+// Manifests are only loaded when loadManifests is true, otherwise they are loaded on demand by loadPluginCommands.
+func registerPlugins(ctx context.Context, commands *Commands, loadManifests bool) {
+	plugins := findPlugins(ctx)
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if commands.hasNamespace(name) {
+			continue
+		}
+		addPluginNamespace(commands, name, plugins[name])
+		if loadManifests {
+			loadPluginCommands(ctx, commands, name)
+		}
+	}
+}
+
+// registerPlugin adds the namespace of the plugin of the given name, e.g. scw-ourteam for ourteam,
+// if it is not a namespace of the CLI and its executable is found in PATH.
+func registerPlugin(ctx context.Context, commands *Commands, name string) {
+	if runtime.GOOS == "js" || !pluginNameRegex.MatchString(name) || commands.hasNamespace(name) {
+		return
+	}
+	if path := lookPlugin(ctx, name); path != "" {
+		addPluginNamespace(commands, name, path)
+	}
+}
+
+// hasNamespace returns true if a command has the given namespace.
+func (c *Commands) hasNamespace(name string) bool {
+	for _, cmd := range c.commands {
+		if cmd.Namespace == name {
+			return true
+		}
+	}
+	return false
+}
+
+func addPluginNamespace(commands *Commands, name string, path string) {
+	commands.Add(&Command{
+		Namespace:    name,
+		Short:        "Run the " + filepath.Base(path) + " plugin",
+		Groups:       []string{pluginGroup},
+		LocalEffects: true,
+		pluginPath:   path,
+	})
+}
+
+// lookPlugin returns the path of the executable of a plugin in PATH, like exec.LookPath with the PATH of the context.
+func lookPlugin(ctx context.Context, name string) string {
+	fileName := pluginPrefix + name
+	if runtime.GOOS == "windows" {
+		fileName += ".exe"
+	}
+	for _, dir := range filepath.SplitList(ExtractEnv(ctx, "PATH")) {
+		path := filepath.Join(dir, fileName)
+		if isPluginExecutable(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+func isPluginExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir() && (runtime.GOOS == "windows" || info.Mode()&0o111 != 0)
+}
+
+// loadPluginCommands adds the commands listed in the manifest of a plugin, if name is the namespace of a plugin.
+func loadPluginCommands(ctx context.Context, commands *Commands, name string) {
+	namespaceCmd := commands.Find(name)
+	if namespaceCmd == nil || namespaceCmd.pluginPath == "" || namespaceCmd.pluginManifestLoaded {
+		return
+	}
+	namespaceCmd.pluginManifestLoaded = true
+
+	manifest := loadPluginManifest(ctx, namespaceCmd.pluginPath)
+	if manifest.Short != "" {
+		namespaceCmd.Short = manifest.Short
+	}
+	namespaceCmd.Long = manifest.Long
+
+	for _, manifestCommand := range manifest.Commands {
+		if manifestCommand == nil {
+			continue
+		}
+		// Commands have a resource and a verb at most.
+		words := strings.Fields(manifestCommand.Path)
+		if len(words) == 0 || len(words) > 2 {
+			continue
+		}
+
+		cmd := &Command{
+			Namespace:    name,
+			Resource:     words[0],
+			Short:        manifestCommand.Short,
+			LocalEffects: true,
+			pluginPath:   namespaceCmd.pluginPath,
+		}
+		if len(words) == 2 {
+			cmd.Verb = words[1]
+		}
+		for _, arg := range manifestCommand.Args {
+			cmd.ArgSpecs = append(cmd.ArgSpecs, &ArgSpec{Name: arg})
+		}
+		commands.Add(cmd)
+	}
+}
+
+// isRootUsage returns true if args print the usage of the CLI, which lists the namespaces, e.g. scw -h.
+func isRootUsage(args []string) bool {
+	words := []string(nil)
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			words = append(words, arg)
+		}
+	}
+	return len(words) == 0 || (len(words) == 1 && words[0] == "help")
+}
+
+// loadPluginManifest returns the manifest of a plugin, manifests are cached until the executable changes.
+// Plugins that do not print a manifest are listed without description.
+func loadPluginManifest(ctx context.Context, path string) *pluginManifest {
+	manifest := &pluginManifest{}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return manifest
+	}
+	// The key changes with the executable, entries of previous versions expire.
+	cacheKey := fmt.Sprintf("%s %d %d", path, info.ModTime().UnixNano(), info.Size())
+	if ExtractCache(ctx).Get(pluginCacheNamespace, cacheKey, manifest) {
+		return manifest
+	}
+
+	manifestCtx, cancel := context.WithTimeout(ctx, pluginManifestTimeout)
+	defer cancel()
+
+	stdout := &bytes.Buffer{}
+	cmd := exec.CommandContext(manifestCtx, path, pluginManifestArg)
+	cmd.Stdin = &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = io.Discard
+	exitCode, err := ExecCmd(ctx, cmd)
+	if err == nil && exitCode != 0 {
+		err = fmt.Errorf("exit code %d", exitCode)
+	}
+	if err == nil {
+		err = json.Unmarshal(stdout.Bytes(), manifest)
+	}
+	if err != nil {
+		ExtractLogger(ctx).Debugf("cannot read manifest of plugin %s: %s\n", path, err)
+		manifest = &pluginManifest{}
+	}
+
+	// Plugins without manifest are cached too so that they are not run each time.
+	ExtractCache(ctx).Set(pluginCacheNamespace, cacheKey, manifest, pluginManifestCacheTTL)
+	return manifest
+}
+
+// findPluginCommand returns the plugin command run by args, if any, and the args given to the plugin.
+// Global flags of the CLI can be given before the namespace, e.g. scw -p prod ourteam server list.
+func findPluginCommand(commands *Commands, args []string, globalFlags *pflag.FlagSet) (*Command, []string) {
+	i := namespaceIndex(args, globalFlags)
+	if i < 0 {
+		return nil, nil
+	}
+
+	cmd := commands.Find(args[i])
+	if cmd == nil || cmd.pluginPath == "" {
+		return nil, nil
+	}
+	return cmd, args[i+1:]
+}
+
+// namespaceIndex returns the index of the namespace in args, after the global flags, or -1 if there is none.
+func namespaceIndex(args []string, globalFlags *pflag.FlagSet) int {
+	i := 0
+	for i < len(args) && strings.HasPrefix(args[i], "-") {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		flag := globalFlags.Lookup(name)
+		if flag == nil && len(name) == 1 {
+			flag = globalFlags.ShorthandLookup(name)
+		}
+		if flag == nil {
+			return -1
+		}
+		// Flags that are not booleans take the next arg as value, e.g. -p prod.
+		if !hasValue && flag.NoOptDefVal == "" {
+			i++
+		}
+		i++
+	}
+	if i >= len(args) {
+		return -1
+	}
+	return i
+}
+
+// runPlugin runs a plugin executable with the remaining args.
+// The plugin receives the settings resolved by the CLI in the environment variables read by the Scaleway SDKs.
+func runPlugin(ctx context.Context, cmd *Command, args []string) (int, error) {
+	pluginCmd := exec.Command(cmd.pluginPath, args...) //nolint:gosec
+	pluginCmd.Env = os.Environ()
+	for key, value := range extractMeta(ctx).OverrideEnv {
+		pluginCmd.Env = append(pluginCmd.Env, key+"="+value)
+	}
+	pluginCmd.Env = append(pluginCmd.Env, pluginEnv(ctx)...)
+
+	exitCode, err := ExecCmd(ctx, pluginCmd)
+	if err != nil {
+		return 1, &CliError{
+			Err: fmt.Errorf("cannot run plugin %s: %w", cmd.pluginPath, err),
+		}
+	}
+	return exitCode, nil
+}
+
+// pluginEnv returns the environment variables describing the profile used by the CLI.
+func pluginEnv(ctx context.Context) []string {
+	meta := extractMeta(ctx)
+	env := []string{
+		scw.ScwConfigPathEnv + "=" + ExtractConfigPath(ctx),
+		scw.ScwActiveProfileEnv + "=" + ExtractProfileName(ctx),
+	}
+
+	client := meta.Client
+	if !meta.isClientFromBootstrapConfig {
+		var err error
+		client, err = createClient(ctx)
+		if err != nil {
+			// Plugins may not need credentials, they get the settings that could be resolved.
+			ExtractLogger(ctx).Debugf("cannot create client for plugin: %s\n", err)
+			return env
+		}
+	}
+
+	if accessKey, exists := client.GetAccessKey(); exists {
+		env = append(env, scw.ScwAccessKeyEnv+"="+accessKey)
+	}
+	if secretKey, exists := client.GetSecretKey(); exists {
+		env = append(env, scw.ScwSecretKeyEnv+"="+secretKey)
+	}
+	if organizationID, exists := client.GetDefaultOrganizationID(); exists {
+		env = append(env, scw.ScwDefaultOrganizationIDEnv+"="+organizationID)
+	}
+	if projectID, exists := client.GetDefaultProjectID(); exists {
+		env = append(env, scw.ScwDefaultProjectIDEnv+"="+projectID)
+	}
+	if region, exists := client.GetDefaultRegion(); exists {
+		env = append(env, scw.ScwDefaultRegionEnv+"="+region.String())
+	}
+	if zone, exists := client.GetDefaultZone(); exists {
+		env = append(env, scw.ScwDefaultZoneEnv+"="+zone.String())
+	}
+	return env
+}
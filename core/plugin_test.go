@@ -0,0 +1,176 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/stretchr/testify/require"
+)
+
+const testPluginScript = `#!/bin/sh
+if [ "$1" = "--scw-plugin-manifest" ]; then
+  echo '{"short": "Manage flower bouquets", "commands": [{"path": "bouquet create", "short": "Create a bouquet", "args": ["size", "color"]}]}'
+  exit 0
+fi
+echo "args: $*"
+echo "profile: $SCW_PROFILE"
+echo "access key: $SCW_ACCESS_KEY"
+echo "zone: $SCW_DEFAULT_ZONE"
+exit 3
+`
+
+func Test_Plugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts in tests")
+	}
+
+	pluginDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(pluginDir, "scw-flower"), []byte(testPluginScript), 0o700) //nolint:gosec
+	require.NoError(t, err)
+	// Plugins cannot replace the namespaces of the CLI.
+	err = os.WriteFile(filepath.Join(pluginDir, "scw-test"), []byte(testPluginScript), 0o700) //nolint:gosec
+	require.NoError(t, err)
+
+	// Plugins are registered in the commands, each test has its own.
+	commands := func() *core.Commands {
+		return core.NewCommands(
+			&core.Command{
+				Namespace:            "test",
+				Resource:             "autocomplete",
+				AllowAnonymousClient: true,
+				ArgsType:             reflect.TypeOf(struct{}{}),
+				Run: func(ctx context.Context, _ interface{}) (interface{}, error) {
+					suggestions := core.AutoComplete(ctx, []string{"scw", "flower", "bouquet", "create"}, "", nil).Suggestions
+					return strings.Join(suggestions, " "), nil
+				},
+			},
+			&core.Command{
+				Namespace:            "test",
+				Resource:             "complete-namespace",
+				AllowAnonymousClient: true,
+				ArgsType:             reflect.TypeOf(struct{}{}),
+				Run: func(ctx context.Context, _ interface{}) (interface{}, error) {
+					suggestions := core.AutoComplete(ctx, []string{"scw"}, "flo", nil).Suggestions
+					return strings.Join(suggestions, " "), nil
+				},
+			},
+			&core.Command{
+				Namespace:            "test",
+				Resource:             "flower-registered",
+				AllowAnonymousClient: true,
+				ArgsType:             reflect.TypeOf(struct{}{}),
+				Run: func(ctx context.Context, _ interface{}) (interface{}, error) {
+					return core.ExtractCommands(ctx).Find("flower") != nil, nil
+				},
+			},
+		)
+	}
+	overrideEnv := map[string]string{
+		"PATH": pluginDir,
+	}
+
+	t.Run("run", core.Test(&core.TestConfig{
+		Commands:    commands(),
+		TmpHomeDir:  true,
+		OverrideEnv: overrideEnv,
+		Cmd:         "scw flower bouquet create size=3 --verbose",
+		// Manifests are only loaded to list or complete the commands of plugins.
+		OverrideExec: func(ctx *core.ExecFuncCtx, cmd *exec.Cmd) (int, error) {
+			require.NotContains(ctx.T, cmd.Args, "--scw-plugin-manifest")
+			err := cmd.Run()
+			if exitErr, isExitErr := err.(*exec.ExitError); isExitErr {
+				return exitErr.ExitCode(), nil
+			}
+			return 0, err
+		},
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(3),
+		),
+	}))
+
+	t.Run("global flags before namespace", core.Test(&core.TestConfig{
+		Commands:    commands(),
+		TmpHomeDir:  true,
+		OverrideEnv: overrideEnv,
+		Cmd:         "scw -o json --yes flower bouquet create size=3",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(3),
+		),
+	}))
+
+	t.Run("usage", core.Test(&core.TestConfig{
+		Commands:    commands(),
+		TmpHomeDir:  true,
+		OverrideEnv: overrideEnv,
+		Cmd:         "scw -h",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("autocomplete", core.Test(&core.TestConfig{
+		Commands:    commands(),
+		TmpHomeDir:  true,
+		OverrideEnv: overrideEnv,
+		Cmd:         "scw test autocomplete",
+		Check: core.TestCheckCombine(
+			core.TestCheckStdout("color= size=\n"),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("autocomplete namespace", core.Test(&core.TestConfig{
+		Commands:    commands(),
+		TmpHomeDir:  true,
+		OverrideEnv: overrideEnv,
+		Cmd:         "scw test complete-namespace",
+		Check: core.TestCheckCombine(
+			core.TestCheckStdout("flower\n"),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	// PATH is not scanned when the namespace of the command is known.
+	t.Run("known namespace", core.Test(&core.TestConfig{
+		Commands:    commands(),
+		TmpHomeDir:  true,
+		OverrideEnv: overrideEnv,
+		Cmd:         "scw test flower-registered",
+		Check: core.TestCheckCombine(
+			core.TestCheckStdout("false\n"),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("dry run", core.Test(&core.TestConfig{
+		Commands:    commands(),
+		TmpHomeDir:  true,
+		OverrideEnv: overrideEnv,
+		Cmd:         "scw --dry-run flower bouquet create size=3",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	t.Run("dry run after namespace", core.Test(&core.TestConfig{
+		Commands:    commands(),
+		TmpHomeDir:  true,
+		OverrideEnv: overrideEnv,
+		Cmd:         "scw flower bouquet create size=3 --dry-run",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+}
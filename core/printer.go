@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"reflect"
 	"strings"
 	"text/template"
@@ -37,6 +38,21 @@ const (
 	// PrinterTypeTemplate defines a go template to use to format output.
 	PrinterTypeTemplate = PrinterType("template")
 
+	// PrinterTypeCSV defines a comma-separated values formatter.
+	PrinterTypeCSV = PrinterType("csv")
+
+	// PrinterTypeTSV defines a tab-separated values formatter.
+	PrinterTypeTSV = PrinterType("tsv")
+
+	// PrinterTypeNDJSON defines a newline-delimited JSON formatter.
+	PrinterTypeNDJSON = PrinterType("ndjson")
+
+	// PrinterTypeMarkdown defines a Markdown tables formatter.
+	PrinterTypeMarkdown = PrinterType("markdown")
+
+	// PrinterTypeHTML defines a HTML tables formatter.
+	PrinterTypeHTML = PrinterType("html")
+
 	// Option to enable pretty output on json printer.
 	PrinterOptJSONPretty = "pretty"
 )
@@ -45,6 +61,9 @@ type PrinterConfig struct {
 	OutputFlag string
 	Stdout     io.Writer
 	Stderr     io.Writer
+
+	// OverrideEnv overrides environment variables returned by the env function of the template printer.
+	OverrideEnv map[string]string
 }
 
 // NewPrinter returns an initialized formatter corresponding to a given FormatterType.
@@ -69,14 +88,24 @@ func NewPrinter(config *PrinterConfig) (*Printer, error) {
 	case PrinterTypeWide.String():
 		setupWidePrinter(printer, printerOpt)
 	case PrinterTypeJSON.String():
-		err := setupJSONPrinter(printer, printerOpt)
+		setupJSONPrinter(printer, printerOpt)
+	case PrinterTypeYAML.String():
+		setupYAMLPrinter(printer, printerOpt)
+	case PrinterTypeTemplate.String():
+		err := setupTemplatePrinter(printer, printerOpt, config.OverrideEnv)
 		if err != nil {
 			return nil, err
 		}
-	case PrinterTypeYAML.String():
-		printer.printerType = PrinterTypeYAML
-	case PrinterTypeTemplate.String():
-		err := setupTemplatePrinter(printer, printerOpt)
+	case PrinterTypeCSV.String():
+		setupTablePrinter(printer, PrinterTypeCSV, printerOpt)
+	case PrinterTypeTSV.String():
+		setupTablePrinter(printer, PrinterTypeTSV, printerOpt)
+	case PrinterTypeMarkdown.String():
+		setupTablePrinter(printer, PrinterTypeMarkdown, printerOpt)
+	case PrinterTypeHTML.String():
+		setupTablePrinter(printer, PrinterTypeHTML, printerOpt)
+	case PrinterTypeNDJSON.String():
+		err := setupNDJSONPrinter(printer, printerOpt)
 		if err != nil {
 			return nil, err
 		}
@@ -88,19 +117,34 @@ func NewPrinter(config *PrinterConfig) (*Printer, error) {
 	return printer, nil
 }
 
-func setupJSONPrinter(printer *Printer, opts string) error {
+// setupJSONPrinter accepts the pretty option and a list of fields to print, e.g. json=pretty,id,public_ip.address.
+// Other options are taken as fields, unknown ones fail when the result is printed.
+func setupJSONPrinter(printer *Printer, opts string) {
 	printer.printerType = PrinterTypeJSON
-	switch opts {
-	case PrinterOptJSONPretty:
-		printer.jsonPretty = true
-	case "":
-	default:
-		return fmt.Errorf("invalid option %s for json outout. Valid options are: %s", opts, PrinterOptJSONPretty)
+	for _, opt := range splitListOption(opts) {
+		if opt == PrinterOptJSONPretty {
+			printer.jsonPretty = true
+			continue
+		}
+		printer.projectedFields = append(printer.projectedFields, opt)
+	}
+}
+
+// setupYAMLPrinter accepts a list of fields to print, e.g. yaml=id,public_ip.address.
+func setupYAMLPrinter(printer *Printer, opts string) {
+	printer.printerType = PrinterTypeYAML
+	printer.projectedFields = splitListOption(opts)
+}
+
+func setupNDJSONPrinter(printer *Printer, opts string) error {
+	printer.printerType = PrinterTypeNDJSON
+	if opts != "" {
+		return fmt.Errorf("invalid option %s for ndjson output. This output does not accept options", opts)
 	}
 	return nil
 }
 
-func setupTemplatePrinter(printer *Printer, opts string) error {
+func setupTemplatePrinter(printer *Printer, opts string, overrideEnv map[string]string) error {
 	printer.printerType = PrinterTypeTemplate
 	if opts == "" {
 		return &CliError{
@@ -110,7 +154,19 @@ func setupTemplatePrinter(printer *Printer, opts string) error {
 		}
 	}
 
-	t, err := template.New("OutputFormat").Parse(opts)
+	text, err := loadTemplate(opts)
+	if err != nil {
+		return err
+	}
+
+	getenv := func(key string) string {
+		if value, exists := overrideEnv[key]; exists {
+			return value
+		}
+		return os.Getenv(key)
+	}
+
+	t, err := template.New("OutputFormat").Funcs(templateFuncs(getenv)).Parse(text)
 	if err != nil {
 		return err
 	}
@@ -131,6 +187,11 @@ func setupWidePrinter(printer *Printer, opts string) {
 	printer.printerType = PrinterTypeWide
 }
 
+func setupTablePrinter(printer *Printer, printerType PrinterType, opts string) {
+	setupHumanPrinter(printer, opts)
+	printer.printerType = printerType
+}
+
 type Printer struct {
 	printerType PrinterType
 	stdout      io.Writer
@@ -142,8 +203,11 @@ type Printer struct {
 	// go template to use on template output
 	template *template.Template
 
-	// Allow to select specifics column in a table with human printer
+	// Allow to select specifics column in a table with human, csv, tsv, markdown and html printers
 	humanFields []string
+
+	// Allow to select the fields printed by json and yaml printers
+	projectedFields []string
 }
 
 func (p *Printer) Print(data interface{}, opt *human.MarshalOpt) error {
@@ -165,10 +229,26 @@ func (p *Printer) Print(data interface{}, opt *human.MarshalOpt) error {
 		err = p.printYAML(data)
 	case PrinterTypeTemplate:
 		err = p.printTemplate(data)
+	case PrinterTypeCSV:
+		err = p.printCSV(data, opt, ',')
+	case PrinterTypeTSV:
+		err = p.printCSV(data, opt, '\t')
+	case PrinterTypeNDJSON:
+		err = p.printNDJSON(data)
+	case PrinterTypeMarkdown:
+		err = p.printDocument(data, opt, human.FormatMarkdown)
+	case PrinterTypeHTML:
+		err = p.printDocument(data, opt, human.FormatHTML)
 	default:
 		err = fmt.Errorf("unknown format: %s", p.printerType)
 	}
 
+	// Errors about the output options, e.g. an unknown column, are returned so that the command exits with an error.
+	cliErr := (*CliError)(nil)
+	if errors.As(err, &cliErr) {
+		return cliErr
+	}
+
 	if err != nil {
 		// if the printer itself returns an error, don't try to format it just print it
 		_, err := fmt.Fprintln(p.stderr, err.Error())
@@ -225,6 +305,20 @@ func (p *Printer) printHuman(data interface{}, opt *human.MarshalOpt) error {
 	return err
 }
 
+// printDocument prints data like the human printer but with tables and titles that can be pasted in documents.
+// Errors are printed as usual on stderr.
+func (p *Printer) printDocument(data interface{}, opt *human.MarshalOpt, format human.Format) error {
+	if _, isError := data.(error); isError {
+		return p.printHuman(data, nil)
+	}
+
+	if opt == nil {
+		opt = &human.MarshalOpt{}
+	}
+	opt.Format = format
+	return p.printHuman(data, opt)
+}
+
 func (p *Printer) printWide(data interface{}, opt *human.MarshalOpt) error {
 	if opt != nil {
 		opt.DisableShrinking = true
@@ -246,6 +340,14 @@ func (p *Printer) printJSON(data interface{}) error {
 		}
 	}
 
+	if !isError && len(p.projectedFields) > 0 {
+		projected, err := projectFields(data, p.projectedFields, jsonFieldKey)
+		if err != nil {
+			return err
+		}
+		data = projected
+	}
+
 	writer := p.stdout
 	if isError {
 		writer = p.stderr
@@ -256,7 +358,7 @@ func (p *Printer) printJSON(data interface{}) error {
 	}
 
 	// We handle special case to make sure that a nil slice is marshal as `[]`
-	if reflect.TypeOf(data).Kind() == reflect.Slice && reflect.ValueOf(data).IsNil() {
+	if data != nil && reflect.TypeOf(data).Kind() == reflect.Slice && reflect.ValueOf(data).IsNil() {
 		_, err := p.stdout.Write([]byte("[]\n"))
 		return err
 	}
@@ -274,6 +376,14 @@ func (p *Printer) printYAML(data interface{}) error {
 		}
 	}
 
+	if !isError && len(p.projectedFields) > 0 {
+		projected, err := projectFields(data, p.projectedFields, yamlFieldKey)
+		if err != nil {
+			return err
+		}
+		data = projected
+	}
+
 	writer := p.stdout
 	if isError {
 		writer = p.stderr
@@ -0,0 +1,231 @@
+package core
+
+import (
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/core/human"
+	"github.com/scaleway/scaleway-cli/v2/internal/gofields"
+)
+
+// printCSV prints data as separated values using the given separator.
+// A list is printed with one row per item, any other value is printed as a single row.
+func (p *Printer) printCSV(data interface{}, opt *human.MarshalOpt, separator rune) error {
+	if _, isError := data.(error); isError {
+		return p.printHuman(data, nil)
+	}
+
+	// Values with a custom human representation (e.g. success messages) do not have columns.
+	if _, isMultiResults := data.(MultiResults); !isMultiResults {
+		if _, isHumanMarshaler := data.(human.Marshaler); isHumanMarshaler {
+			return p.printHuman(data, opt)
+		}
+	}
+
+	dataValue := reflect.ValueOf(data)
+	if !dataValue.IsValid() {
+		return nil
+	}
+
+	items := []reflect.Value(nil)
+	if dataValue.Kind() == reflect.Slice {
+		for i := range dataValue.Len() {
+			items = append(items, dataValue.Index(i))
+		}
+	} else {
+		items = append(items, dataValue)
+	}
+
+	writer := csv.NewWriter(p.stdout)
+	writer.Comma = separator
+
+	itemType := listItemType(dataValue.Type(), items)
+	if itemType.Kind() != reflect.Struct {
+		for _, item := range items {
+			value, err := formatFieldValue(item)
+			if err != nil {
+				return err
+			}
+			if err := writer.Write([]string{value}); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	fields := p.csvFields(itemType, opt)
+	for _, field := range fields {
+		if _, err := gofields.GetType(itemType, field); err != nil {
+			return &CliError{
+				Err:  fmt.Errorf("unknown field '%s' in output options", field),
+				Hint: "Valid fields are: " + strings.Join(gofields.ListFields(itemType), ", "),
+			}
+		}
+	}
+
+	if err := writer.Write(fields); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		row := make([]string, 0, len(fields))
+		for _, field := range fields {
+			v, err := gofields.GetValue(item.Interface(), field)
+			if err != nil {
+				// Nil parents (e.g. a server without public IP) produce an empty cell.
+				row = append(row, "")
+				continue
+			}
+			value, err := formatFieldValue(reflect.ValueOf(v))
+			if err != nil {
+				return err
+			}
+			row = append(row, value)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvFields returns the list of columns to print.
+// Columns selected with the output flag have priority over the ones defined in the command View.
+// If none are defined all fields are flattened using gofields paths.
+func (p *Printer) csvFields(itemType reflect.Type, opt *human.MarshalOpt) []string {
+	if len(p.humanFields) > 0 {
+		return p.humanFields
+	}
+
+	if opt != nil && len(opt.Fields) > 0 {
+		fields := make([]string, 0, len(opt.Fields))
+		for _, field := range opt.Fields {
+			fields = append(fields, field.FieldName)
+		}
+		return fields
+	}
+
+	return flattenFields(itemType, "", map[reflect.Type]bool{})
+}
+
+// listItemType returns the struct type of the items of a list.
+// For a list of interfaces (e.g. MultiResults) the type of the first item is used.
+func listItemType(dataType reflect.Type, items []reflect.Value) reflect.Type {
+	itemType := dataType
+	if itemType.Kind() == reflect.Slice {
+		itemType = itemType.Elem()
+	}
+
+	if itemType.Kind() == reflect.Interface && len(items) > 0 {
+		item := items[0]
+		for item.Kind() == reflect.Interface && !item.IsNil() {
+			item = item.Elem()
+		}
+		if item.IsValid() && item.Kind() != reflect.Interface {
+			itemType = item.Type()
+		}
+	}
+
+	for itemType.Kind() == reflect.Ptr {
+		itemType = itemType.Elem()
+	}
+
+	return itemType
+}
+
+// flattenFields lists all leaf fields of a struct type.
+// Nested structs are flattened (e.g. PublicIP.Address) while lists and maps are kept in a single column.
+func flattenFields(t reflect.Type, prefix string, parents map[reflect.Type]bool) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	parents[t] = true
+	defer delete(parents, t)
+
+	fields := []string(nil)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous {
+			fields = append(fields, flattenFields(field.Type, prefix, parents)...)
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() == reflect.Struct && !isCSVScalar(fieldType) && !parents[fieldType] {
+			fields = append(fields, flattenFields(fieldType, prefix+field.Name+".", parents)...)
+			continue
+		}
+
+		fields = append(fields, prefix+field.Name)
+	}
+
+	return fields
+}
+
+// isCSVScalar returns true if a struct type is printed as a single value.
+func isCSVScalar(t reflect.Type) bool {
+	scalarInterfaces := []reflect.Type{
+		reflect.TypeOf((*fmt.Stringer)(nil)).Elem(),
+		reflect.TypeOf((*json.Marshaler)(nil)).Elem(),
+		reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem(),
+	}
+	for _, scalarInterface := range scalarInterfaces {
+		if t.Implements(scalarInterface) || reflect.PointerTo(t).Implements(scalarInterface) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatFieldValue formats a single field value, e.g. a CSV cell.
+// Dates use RFC3339 and complex values (lists, maps, structs) are encoded in JSON.
+func formatFieldValue(value reflect.Value) (string, error) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", nil
+		}
+		value = value.Elem()
+	}
+
+	if !value.IsValid() {
+		return "", nil
+	}
+
+	switch v := value.Interface().(type) {
+	case time.Time:
+		return v.Format(time.RFC3339), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		if (value.Kind() == reflect.Slice || value.Kind() == reflect.Map) && value.IsNil() {
+			return "", nil
+		}
+		raw, err := json.Marshal(value.Interface())
+		if err != nil {
+			return "", err
+		}
+		return string(raw), nil
+	default:
+		return fmt.Sprint(value.Interface()), nil
+	}
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// printNDJSON prints data as newline-delimited JSON.
+// A list is printed with one item per line, any other value is printed on a single line.
+func (p *Printer) printNDJSON(data interface{}) error {
+	if _, isError := data.(error); isError {
+		return p.printJSON(data)
+	}
+
+	encoder := json.NewEncoder(p.stdout)
+
+	dataValue := reflect.ValueOf(data)
+	if !dataValue.IsValid() || dataValue.Kind() != reflect.Slice {
+		return encoder.Encode(data)
+	}
+
+	for i := range dataValue.Len() {
+		err := encoder.Encode(dataValue.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamsLists returns true if list results can be printed page by page as they are fetched.
+// Other formats need the whole result, e.g. to align the columns of a table.
+func (p *Printer) streamsLists() bool {
+	return p.printerType == PrinterTypeNDJSON
+}
+
+// printListPage prints the items of a page of a list as returned by the API, see listStreamingTransport.
+func (p *Printer) printListPage(items []json.RawMessage) error {
+	for _, item := range items {
+		line := &bytes.Buffer{}
+		err := json.Compact(line, item)
+		if err != nil {
+			return err
+		}
+		line.WriteByte('\n')
+		_, err = p.stdout.Write(line.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
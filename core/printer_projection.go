@@ -0,0 +1,179 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/gofields"
+	"gopkg.in/yaml.v3"
+)
+
+// projectedDocument is a resource restricted to the fields selected with -o json=<fields> or -o yaml=<fields>.
+// Fields are kept in the order they were selected, nested fields are grouped under their parent.
+type projectedDocument struct {
+	keys   []string
+	values []interface{}
+}
+
+// set sets the value of a nested field, e.g. ["public_ip", "address"].
+func (d *projectedDocument) set(keys []string, value interface{}) {
+	for i, key := range d.keys {
+		if key != keys[0] {
+			continue
+		}
+		if child, isDocument := d.values[i].(*projectedDocument); isDocument && len(keys) > 1 {
+			child.set(keys[1:], value)
+		}
+		return
+	}
+
+	if len(keys) > 1 {
+		child := &projectedDocument{}
+		child.set(keys[1:], value)
+		value = child
+	}
+	d.keys = append(d.keys, keys[0])
+	d.values = append(d.values, value)
+}
+
+func (d *projectedDocument) MarshalJSON() ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	buffer.WriteByte('{')
+	for i, key := range d.keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		rawKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		rawValue, err := json.Marshal(d.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(rawKey)
+		buffer.WriteByte(':')
+		buffer.Write(rawValue)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+func (d *projectedDocument) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for i, key := range d.keys {
+		keyNode := &yaml.Node{}
+		err := keyNode.Encode(key)
+		if err != nil {
+			return nil, err
+		}
+		valueNode := &yaml.Node{}
+		err = valueNode.Encode(d.values[i])
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, keyNode, valueNode)
+	}
+	return node, nil
+}
+
+// projectFields restricts data to the fields selected in the output flag.
+// A list is projected item by item, keys are named as they would be by fieldKey.
+func projectFields(data interface{}, fields []string, fieldKey func(reflect.StructField) string) (interface{}, error) {
+	dataValue := reflect.ValueOf(data)
+	if !dataValue.IsValid() {
+		return data, nil
+	}
+
+	if dataValue.Kind() != reflect.Slice {
+		return projectItem(dataValue, fields, fieldKey)
+	}
+
+	documents := make([]interface{}, 0, dataValue.Len())
+	for i := range dataValue.Len() {
+		document, err := projectItem(dataValue.Index(i), fields, fieldKey)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+func projectItem(item reflect.Value, fields []string, fieldKey func(reflect.StructField) string) (interface{}, error) {
+	for item.Kind() == reflect.Interface || item.Kind() == reflect.Ptr {
+		if item.IsNil() {
+			return nil, nil
+		}
+		item = item.Elem()
+	}
+
+	if item.Kind() != reflect.Struct {
+		return nil, &CliError{
+			Err:  fmt.Errorf("cannot select fields in a result of type %s", item.Type()),
+			Hint: "Remove the list of fields from the output flag",
+		}
+	}
+
+	document := &projectedDocument{}
+	for _, field := range fields {
+		path, err := resolveFieldPath(item.Type(), field, "output options")
+		if err != nil {
+			return nil, err
+		}
+
+		// Nil parents (e.g. a server without public IP) produce a null value.
+		value, err := gofields.GetValue(item.Interface(), path)
+		if err != nil {
+			value = nil
+		}
+
+		document.set(projectedKeys(item.Type(), path, fieldKey), value)
+	}
+	return document, nil
+}
+
+// projectedKeys converts a gofields path to the keys used in the printed document.
+// List indexes and map keys are kept as is.
+func projectedKeys(t reflect.Type, path string, fieldKey func(reflect.StructField) string) []string {
+	keys := []string(nil)
+	for _, segment := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			field, _ := t.FieldByName(segment)
+			keys = append(keys, fieldKey(field))
+			t = field.Type
+		case reflect.Slice, reflect.Map:
+			keys = append(keys, segment)
+			t = t.Elem()
+		default:
+			keys = append(keys, segment)
+		}
+	}
+	return keys
+}
+
+// jsonFieldKey returns the name of a field in its JSON representation.
+func jsonFieldKey(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// yamlFieldKey returns the name of a field in its YAML representation.
+func yamlFieldKey(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "" || name == "-" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"gopkg.in/yaml.v3"
+)
+
+// templateFuncs returns the functions available in templates given with -o template.
+//
+//   - toJson: encodes a value in JSON, e.g. {{ toJson .Tags }}
+//   - toYaml: encodes a value in YAML, e.g. {{ toYaml .PublicIP }}
+//   - join: joins the items of a list, e.g. {{ join ", " .Tags }}
+//   - default: returns a default value if the value is empty, e.g. {{ default "none" .Description }}
+//   - humanizeBytes: formats a size, e.g. {{ humanizeBytes .Size }} gives 20 GB
+//   - since: returns the time elapsed since a date, e.g. {{ since .CreationDate }} gives 3 days
+//   - upper and lower: change the case of a string, e.g. {{ upper .State }}
+//   - indent: indents each line of a string, e.g. {{ toYaml .Volumes | indent 4 }}
+//   - env: returns the value of an environment variable, e.g. {{ env "USER" }}
+func templateFuncs(getenv func(string) string) template.FuncMap {
+	return template.FuncMap{
+		"toJson": func(v interface{}) (string, error) {
+			raw, err := json.Marshal(v)
+			return string(raw), err
+		},
+		"toYaml": func(v interface{}) (string, error) {
+			raw, err := yaml.Marshal(v)
+			return strings.TrimSuffix(string(raw), "\n"), err
+		},
+		"join": func(separator string, list interface{}) (string, error) {
+			value := indirectTemplateValue(list)
+			if !value.IsValid() {
+				return "", nil
+			}
+			if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+				return "", fmt.Errorf("join expects a list, got %s", value.Type())
+			}
+			strs := make([]string, 0, value.Len())
+			for i := range value.Len() {
+				strs = append(strs, templateString(value.Index(i).Interface()))
+			}
+			return strings.Join(strs, separator), nil
+		},
+		"default": func(defaultValue interface{}, v interface{}) interface{} {
+			value := indirectTemplateValue(v)
+			if !value.IsValid() || value.IsZero() {
+				return defaultValue
+			}
+			if (value.Kind() == reflect.Slice || value.Kind() == reflect.Map) && value.Len() == 0 {
+				return defaultValue
+			}
+			return v
+		},
+		"humanizeBytes": func(v interface{}) (string, error) {
+			value := indirectTemplateValue(v)
+			switch value.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return humanize.Bytes(uint64(value.Int())), nil
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				return humanize.Bytes(value.Uint()), nil
+			case reflect.Float32, reflect.Float64:
+				return humanize.Bytes(uint64(value.Float())), nil
+			case reflect.Invalid:
+				return "", nil
+			default:
+				return "", fmt.Errorf("humanizeBytes expects a number, got %s", value.Type())
+			}
+		},
+		"since": func(v interface{}) (string, error) {
+			value := indirectTemplateValue(v)
+			if !value.IsValid() {
+				return "", nil
+			}
+			date, isTime := value.Interface().(time.Time)
+			if !isTime {
+				return "", fmt.Errorf("since expects a date, got %s", value.Type())
+			}
+			return strings.TrimSpace(humanize.RelTime(date, time.Now(), "", "")), nil
+		},
+		"upper": func(v interface{}) string {
+			return strings.ToUpper(templateString(v))
+		},
+		"lower": func(v interface{}) string {
+			return strings.ToLower(templateString(v))
+		},
+		"indent": func(spaces int, v interface{}) string {
+			padding := strings.Repeat(" ", spaces)
+			return padding + strings.ReplaceAll(templateString(v), "\n", "\n"+padding)
+		},
+		"env": getenv,
+	}
+}
+
+// indirectTemplateValue dereferences pointers and interfaces.
+// An invalid value is returned for nil values.
+func indirectTemplateValue(v interface{}) reflect.Value {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// templateString formats a value like a template does when printing it, nil pointers are printed as empty strings.
+func templateString(v interface{}) string {
+	value := indirectTemplateValue(v)
+	if !value.IsValid() {
+		return ""
+	}
+	if stringer, isStringer := v.(fmt.Stringer); isStringer {
+		return stringer.String()
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// loadTemplate returns the template given with -o template.
+// A template starting with @ is read from the given file, e.g. -o template=@report.tpl.
+func loadTemplate(opts string) (string, error) {
+	path, isFile := strings.CutPrefix(opts, "@")
+	if !isFile {
+		return opts, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot read template file: %w", err)
+	}
+
+	// A new line is already printed after each item.
+	return strings.TrimSuffix(string(content), "\n"), nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 func Test_CorePrinter(t *testing.T) {
@@ -228,3 +229,334 @@ func Test_TemplatePrinter(t *testing.T) {
 		Check: core.TestCheckGolden(),
 	}))
 }
+
+func Test_CSVPrinter(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	}
+
+	type Human struct {
+		ID      string   `json:"id"`
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Address *Address `json:"address"`
+	}
+
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace: "get",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return Human{
+					ID:      "111111111-111111111",
+					Name:    "David Copperfield",
+					Tags:    []string{"magic", "illusion"},
+					Address: &Address{City: "Las Vegas", Zip: "89109"},
+				}, nil
+			},
+		},
+		&core.Command{
+			Namespace: "list",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return []*Human{
+					{ID: "111111111-111111111", Name: "David Copperfield", Address: &Address{City: "Las Vegas", Zip: "89109"}},
+					{ID: "222222222-222222222", Name: "Niel, \"Xavier\"", Tags: []string{"free"}},
+				}, nil
+			},
+			View: &core.View{
+				Fields: []*core.ViewField{
+					{FieldName: "ID", Label: "ID"},
+					{FieldName: "Address.City", Label: "City"},
+				},
+			},
+		},
+	)
+
+	t.Run("csv-simple-without-option", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw get -o csv",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("csv-simple-with-options", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw get -o csv=Name,Address.Zip",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("csv-list-without-option", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o csv",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("csv-list-with-options", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o csv=Name,Tags,Address.Zip",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("csv-list-with-options-unknown-column", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o csv=Name,Unknown",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+	}))
+
+	t.Run("tsv-list-with-options", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o tsv=ID,Name",
+		Check:    core.TestCheckGolden(),
+	}))
+}
+
+func Test_DocumentPrinter(t *testing.T) {
+	type IP struct {
+		ID      string `json:"id"`
+		Address string `json:"address"`
+	}
+
+	type LB struct {
+		ID          string   `json:"id"`
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Tags        []string `json:"tags"`
+		IP          []*IP    `json:"ip"`
+	}
+
+	lbs := []*LB{
+		{
+			ID:          "111111111-111111111",
+			Name:        "web",
+			Description: "front <public> | http",
+			Tags:        []string{"production"},
+			IP:          []*IP{{ID: "333333333-333333333", Address: "51.15.0.1"}},
+		},
+		{ID: "222222222-222222222", Name: "internal"},
+	}
+
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace: "get",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return lbs[0], nil
+			},
+			View: &core.View{
+				Title: "Load Balancer",
+				Sections: []*core.ViewSection{
+					{FieldName: "IP", Title: "IPs"},
+				},
+			},
+		},
+		&core.Command{
+			Namespace: "list",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return lbs, nil
+			},
+			View: &core.View{
+				Fields: []*core.ViewField{
+					{FieldName: "ID", Label: "ID"},
+					{FieldName: "Name", Label: "Name"},
+					{FieldName: "Description", Label: "Description"},
+				},
+			},
+		},
+	)
+
+	t.Run("markdown-get", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw get -o markdown",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("markdown-list", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o markdown",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("markdown-list-with-options", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o markdown=Name,Tags",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("html-get", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw get -o html",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("html-list", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o html",
+		Check:    core.TestCheckGolden(),
+	}))
+}
+
+func Test_TemplatePrinterFunctions(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	}
+
+	type Human struct {
+		ID          string   `json:"id"`
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Tags        []string `json:"tags"`
+		Size        scw.Size `json:"size"`
+		Address     *Address `json:"address"`
+	}
+
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace: "list",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return []*Human{
+					{ID: "111111111-111111111", Name: "David Copperfield", Tags: []string{"magic", "illusion"}, Size: 20 * scw.GB, Address: &Address{City: "Las Vegas", Zip: "89109"}},
+					{ID: "222222222-222222222", Name: "Xavier Niel", Description: "Founder", Size: 1500 * scw.MB},
+				}, nil
+			},
+		},
+	)
+
+	t.Run("functions", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args: []string{
+			// We escape this sequence because there is already golang template rendering on commands in core.Test
+			"scw", "list", "-o", "{{`template={{ upper .Name }} [{{ join \",\" .Tags }}] {{ default \"no description\" .Description }} {{ humanizeBytes .Size }} {{ toJson .Address }}`}}",
+		},
+		Check: core.TestCheckGolden(),
+	}))
+
+	t.Run("env", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args: []string{
+			"scw", "list", "-o", "{{`template={{ lower .Name }} managed by {{ env \"TEAM\" }}`}}",
+		},
+		OverrideEnv: map[string]string{
+			"TEAM": "Infra",
+		},
+		Check: core.TestCheckGolden(),
+	}))
+
+	t.Run("file", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o template=@testdata/template-printer-report.tpl",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("missing-file", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o template=@testdata/unknown.tpl",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+	}))
+}
+
+func Test_ProjectedFieldsPrinter(t *testing.T) {
+	type IP struct {
+		ID      string `json:"id"`
+		Address string `json:"address"`
+	}
+
+	type Server struct {
+		ID       string   `json:"id"`
+		Name     string   `json:"name"`
+		Tags     []string `json:"tags"`
+		PublicIP *IP      `json:"public_ip"`
+	}
+
+	servers := []*Server{
+		{
+			ID:       "11111111-1111-1111-1111-111111111111",
+			Name:     "web",
+			Tags:     []string{"prod"},
+			PublicIP: &IP{ID: "33333333-3333-3333-3333-333333333333", Address: "51.15.251.251"},
+		},
+		{
+			ID:   "22222222-2222-2222-2222-222222222222",
+			Name: "db",
+		},
+	}
+
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace: "get",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return servers[0], nil
+			},
+		},
+		&core.Command{
+			Namespace: "list",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return servers, nil
+			},
+		},
+		&core.Command{
+			Namespace: "names",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return []string{"web", "db"}, nil
+			},
+		},
+	)
+
+	t.Run("json-simple", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw get -o json=id,public_ip.address",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("json-list-pretty", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o json=pretty,name,public_ip.address,public_ip.id,tags",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("yaml-list", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o yaml=Name,PublicIP.Address",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("unknown-field", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o json=name,unknown",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+	}))
+
+	t.Run("not-a-resource", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw names -o yaml=name",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+	}))
+
+	// Unknown options are taken as fields, the typo fails instead of printing the whole result.
+	t.Run("invalid-json-option", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw list -o json=prety",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+	}))
+}
@@ -0,0 +1,105 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+)
+
+type projectConfigArgs struct{}
+
+func Test_ProjectConfig(t *testing.T) {
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "profile",
+			Verb:                 "get",
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(projectConfigArgs{}),
+			Run: func(ctx context.Context, _ interface{}) (interface{}, error) {
+				return core.ExtractProfileName(ctx), nil
+			},
+		},
+	)
+
+	// The project config file is in the working directory of the test, i.e. its home directory.
+	writeProjectConfig := func(name string, content string) core.BeforeFunc {
+		return func(ctx *core.BeforeFuncCtx) error {
+			path := filepath.Join(ctx.OverrideEnv["HOME"], name)
+			err := os.MkdirAll(filepath.Dir(path), 0o700)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(path, []byte(content), 0o600)
+		}
+	}
+	projectConfig := writeProjectConfig(".scw.yaml", `profile: prod
+output: json
+aliases:
+  prof:
+    - test
+    - profile
+    - get
+`)
+
+	t.Run("profile output and aliases", core.Test(&core.TestConfig{
+		Commands:      commands,
+		TmpHomeDir:    true,
+		EnableAliases: true,
+		BeforeFunc:    projectConfig,
+		Cmd:           "scw prof",
+		Check:         core.TestCheckGolden(),
+	}))
+
+	t.Run("flags have priority", core.Test(&core.TestConfig{
+		Commands:      commands,
+		TmpHomeDir:    true,
+		EnableAliases: true,
+		BeforeFunc:    projectConfig,
+		Cmd:           "scw -p staging -o human prof",
+		Check:         core.TestCheckGolden(),
+	}))
+
+	t.Run("config directory", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: writeProjectConfig(".scw/config.yaml", "profile: dev\n"),
+		Cmd:        "scw test profile get",
+		Check:      core.TestCheckGolden(),
+	}))
+
+	t.Run("invalid", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: writeProjectConfig(".scw.yaml", "default_zone: mars-1\n"),
+		Cmd:        "scw test profile get",
+		Check: core.TestCheckCombine(
+			core.TestCheckGoldenAndReplacePatterns(core.GoldenReplacement{
+				Pattern:     regexp.MustCompile(`/tmp/scw[0-9]+`),
+				Replacement: "/tmp/scw",
+			}),
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	// A project config file cannot change what a command does.
+	t.Run("alias replacing a command word", core.Test(&core.TestConfig{
+		Commands:      commands,
+		TmpHomeDir:    true,
+		EnableAliases: true,
+		BeforeFunc:    writeProjectConfig(".scw.yaml", "aliases:\n  get:\n    - delete\n"),
+		Cmd:           "scw test profile get",
+		Check: core.TestCheckCombine(
+			core.TestCheckGoldenAndReplacePatterns(core.GoldenReplacement{
+				Pattern:     regexp.MustCompile(`/tmp/scw[0-9]+`),
+				Replacement: "/tmp/scw",
+			}),
+			core.TestCheckExitCode(1),
+		),
+	}))
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/scaleway/scaleway-cli/v2/internal/query"
+)
+
+// compileQuery compiles the expression given with the --query flag.
+// It returns a nil query if no expression was given.
+func compileQuery(expression string) (*query.Query, error) {
+	if expression == "" {
+		return nil, nil
+	}
+
+	q, err := query.Compile(expression)
+	if err != nil {
+		hint := "Run 'scw help output' to learn more about queries"
+		syntaxErr := jmespath.SyntaxError{}
+		if errors.As(err, &syntaxErr) {
+			hint = "Check the query at the position marked with ^:\n" + syntaxErr.HighlightLocation() + "\n" + hint
+		}
+		return nil, &CliError{
+			Err:  fmt.Errorf("invalid query: %w", err),
+			Hint: hint,
+		}
+	}
+	return q, nil
+}
+
+// searchResult evaluates the query against the result of a command.
+// Raw results are not structured and are returned as is.
+func searchResult(q *query.Query, result interface{}) (interface{}, error) {
+	if _, isRawResult := result.(RawResult); isRawResult {
+		return result, nil
+	}
+
+	queried, err := q.Search(result)
+	if err != nil {
+		return nil, &CliError{
+			Err:  err,
+			Hint: "Run 'scw help output' to learn more about queries",
+		}
+	}
+	return queried, nil
+}
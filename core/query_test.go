@@ -0,0 +1,108 @@
+package core_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+)
+
+func Test_Query(t *testing.T) {
+	type PublicIP struct {
+		Address string `json:"address"`
+	}
+
+	type Server struct {
+		ID       string    `json:"id"`
+		Name     string    `json:"name"`
+		State    string    `json:"state"`
+		PublicIP *PublicIP `json:"public_ip"`
+	}
+
+	type ListServersResponse struct {
+		Servers []*Server `json:"servers"`
+	}
+
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace: "list",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return &ListServersResponse{
+					Servers: []*Server{
+						{ID: "111111111-111111111", Name: "web", State: "running", PublicIP: &PublicIP{Address: "51.15.0.1"}},
+						{ID: "222222222-222222222", Name: "db", State: "stopped"},
+						{ID: "333333333-333333333", Name: "worker", State: "running", PublicIP: &PublicIP{Address: "51.15.0.3"}},
+					},
+				}, nil
+			},
+		},
+	)
+
+	t.Run("filter-human", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--query", "servers[?state=='running'].public_ip.address"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("filter-json", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "-o", "json", "--query", "servers[?state=='running'].public_ip.address"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("multi-select-human", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--query", "servers[*].{name: name, ip: public_ip.address}"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("object-human", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--query", "servers[0]"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("function-json", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "-o", "json", "--query", "length(servers[?state=='running'])"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("null-json", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "-o", "json", "--query", "servers[1].public_ip.address"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("null-human", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--query", "servers[1].public_ip.address"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("null-yaml", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "-o", "yaml", "--query", "servers[1].public_ip.address"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("search-error", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--query", "length(servers, name)"},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+	}))
+
+	t.Run("invalid-query", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--query", "servers[?state=='running'"},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+	}))
+}
@@ -0,0 +1,345 @@
+package core
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/gofields"
+	"github.com/scaleway/scaleway-sdk-go/strcase"
+)
+
+const resultListOptionsHint = "Run 'scw help output' to learn more about filters and sorting"
+
+// filterOperators lists the operators that can be used in a filter.
+// Two characters operators must be checked first.
+var filterOperators = []string{"!=", "!~", "=", "~"}
+
+// resultFilter is a condition given with the --filter flag, e.g. state=running.
+type resultFilter struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+// resultSortKey is a key given with the --sort-by flag, e.g. -creation_date.
+type resultSortKey struct {
+	Field      string
+	Descending bool
+}
+
+// resultListOptions holds the client-side filters and sort keys applied to list results.
+type resultListOptions struct {
+	filters  []*resultFilter
+	sortKeys []*resultSortKey
+}
+
+// parseResultListOptions parses the values given with the --filter and --sort-by flags.
+// It returns nil if none of them were given.
+func parseResultListOptions(filterFlag string, sortByFlag string) (*resultListOptions, error) {
+	if filterFlag == "" && sortByFlag == "" {
+		return nil, nil
+	}
+
+	options := &resultListOptions{}
+
+	for _, condition := range splitListOption(filterFlag) {
+		filter, err := parseResultFilter(condition)
+		if err != nil {
+			return nil, err
+		}
+		options.filters = append(options.filters, filter)
+	}
+
+	for _, key := range splitListOption(sortByFlag) {
+		sortKey := &resultSortKey{Field: key}
+		switch {
+		case strings.HasPrefix(key, "-"):
+			sortKey = &resultSortKey{Field: key[1:], Descending: true}
+		case strings.HasPrefix(key, "+"):
+			sortKey = &resultSortKey{Field: key[1:]}
+		}
+		if sortKey.Field == "" {
+			return nil, &CliError{
+				Err:  fmt.Errorf("invalid sort key '%s'", key),
+				Hint: resultListOptionsHint,
+			}
+		}
+		options.sortKeys = append(options.sortKeys, sortKey)
+	}
+
+	return options, nil
+}
+
+func splitListOption(value string) []string {
+	parts := []string(nil)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func parseResultFilter(condition string) (*resultFilter, error) {
+	for i := range condition {
+		for _, operator := range filterOperators {
+			if !strings.HasPrefix(condition[i:], operator) {
+				continue
+			}
+			field := strings.TrimSpace(condition[:i])
+			if field == "" {
+				return nil, invalidFilterError(condition)
+			}
+			return &resultFilter{
+				Field:    field,
+				Operator: operator,
+				Value:    condition[i+len(operator):],
+			}, nil
+		}
+	}
+
+	return nil, invalidFilterError(condition)
+}
+
+func invalidFilterError(condition string) error {
+	return &CliError{
+		Err:  fmt.Errorf("invalid filter '%s'", condition),
+		Hint: "Filters must be formatted as key=value, key!=value, key~value or key!~value. " + resultListOptionsHint,
+	}
+}
+
+func resultListOptionsNotAListError() error {
+	return &CliError{
+		Err:  errors.New("--filter and --sort-by can only be used with commands returning a list"),
+		Hint: resultListOptionsHint,
+	}
+}
+
+// checkCommand rejects commands that do not list resources before they run, so that a command modifying resources,
+// e.g. a create command, is not run when its result cannot be filtered.
+// List commands are named list or list-*, e.g. scw instance server list or scw baremetal server list-options.
+func (o *resultListOptions) checkCommand(cmd *Command) error {
+	if o == nil {
+		return nil
+	}
+
+	name := cmd.Verb
+	if name == "" {
+		name = cmd.Resource
+	}
+	if name == "" {
+		name = cmd.Namespace
+	}
+	if name != "list" && !strings.HasPrefix(name, "list-") {
+		return resultListOptionsNotAListError()
+	}
+	return nil
+}
+
+// apply filters and sorts a list result.
+// Options are applied on the items of the list, other results are rejected.
+func (o *resultListOptions) apply(result interface{}) (interface{}, error) {
+	if o == nil {
+		return result, nil
+	}
+
+	resultValue := reflect.ValueOf(result)
+	if !resultValue.IsValid() {
+		return result, nil
+	}
+	if resultValue.Kind() != reflect.Slice {
+		return nil, resultListOptionsNotAListError()
+	}
+
+	items := []reflect.Value(nil)
+	for i := range resultValue.Len() {
+		items = append(items, resultValue.Index(i))
+	}
+	itemType := listItemType(resultValue.Type(), items)
+	// Fields cannot be checked without items when the list is not typed, e.g. MultiResults, the result stays empty.
+	if len(items) == 0 && itemType.Kind() == reflect.Interface {
+		return result, nil
+	}
+
+	filterPaths := make([]string, 0, len(o.filters))
+	for _, filter := range o.filters {
+		path, err := resolveFieldPath(itemType, filter.Field, "--filter")
+		if err != nil {
+			return nil, err
+		}
+		filterPaths = append(filterPaths, path)
+	}
+
+	sortPaths := make([]string, 0, len(o.sortKeys))
+	for _, sortKey := range o.sortKeys {
+		path, err := resolveFieldPath(itemType, sortKey.Field, "--sort-by")
+		if err != nil {
+			return nil, err
+		}
+		sortPaths = append(sortPaths, path)
+	}
+
+	filtered := []reflect.Value(nil)
+	for _, item := range items {
+		match := true
+		for i, filter := range o.filters {
+			itemMatch, err := filter.match(getListItemField(item, filterPaths[i]))
+			if err != nil {
+				return nil, err
+			}
+			if !itemMatch {
+				match = false
+				break
+			}
+		}
+		if match {
+			filtered = append(filtered, item)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		for k, sortKey := range o.sortKeys {
+			left := getListItemField(filtered[i], sortPaths[k])
+			right := getListItemField(filtered[j], sortPaths[k])
+
+			// Missing values are always listed last.
+			if isNilFieldValue(left) || isNilFieldValue(right) {
+				if isNilFieldValue(left) == isNilFieldValue(right) {
+					continue
+				}
+				return isNilFieldValue(right)
+			}
+
+			order := compareFieldValues(left, right)
+			if order == 0 {
+				continue
+			}
+			if sortKey.Descending {
+				return order > 0
+			}
+			return order < 0
+		}
+		return false
+	})
+
+	sorted := reflect.MakeSlice(resultValue.Type(), 0, len(filtered))
+	for _, item := range filtered {
+		sorted = reflect.Append(sorted, item)
+	}
+	return sorted.Interface(), nil
+}
+
+// resolveFieldPath converts a field given by the user (e.g. public_ip.address) to a gofields path.
+// source tells where the field was given in error messages, e.g. --filter.
+func resolveFieldPath(itemType reflect.Type, field string, source string) (string, error) {
+	path, err := gofields.ResolvePath(itemType, field)
+	if err != nil {
+		validFields := []string(nil)
+		if itemType.Kind() == reflect.Struct {
+			for _, validField := range flattenFields(itemType, "", map[reflect.Type]bool{}) {
+				parts := strings.Split(validField, ".")
+				for i := range parts {
+					parts[i] = strcase.ToSnake(parts[i])
+				}
+				validFields = append(validFields, strings.Join(parts, "."))
+			}
+		}
+		return "", &CliError{
+			Err:  fmt.Errorf("unknown field '%s' in %s", field, source),
+			Hint: "Valid fields are: " + strings.Join(validFields, ", "),
+		}
+	}
+	return path, nil
+}
+
+// getListItemField returns the value of a field of a list item.
+// An invalid value is returned if the field or one of its parents is nil.
+func getListItemField(item reflect.Value, path string) reflect.Value {
+	v, err := gofields.GetValue(item.Interface(), path)
+	if err != nil {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(v)
+}
+
+// match checks a field value against the filter.
+// A list matches if one of its elements matches, e.g. tags~prod.
+func (f *resultFilter) match(value reflect.Value) (bool, error) {
+	candidates := []reflect.Value{value}
+	if value.IsValid() && (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) {
+		candidates = nil
+		for i := range value.Len() {
+			candidates = append(candidates, value.Index(i))
+		}
+	}
+
+	found := false
+	for _, candidate := range candidates {
+		str, err := formatFieldValue(candidate)
+		if err != nil {
+			return false, err
+		}
+
+		switch f.Operator {
+		case "=", "!=":
+			found = str == f.Value
+		default:
+			found = strings.Contains(strings.ToLower(str), strings.ToLower(f.Value))
+		}
+		if found {
+			break
+		}
+	}
+
+	if strings.HasPrefix(f.Operator, "!") {
+		return !found, nil
+	}
+	return found, nil
+}
+
+func isNilFieldValue(value reflect.Value) bool {
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return true
+		}
+		value = value.Elem()
+	}
+	return !value.IsValid()
+}
+
+// compareFieldValues compares numbers and dates by value and other fields by their string representation.
+func compareFieldValues(left reflect.Value, right reflect.Value) int {
+	for left.Kind() == reflect.Ptr || left.Kind() == reflect.Interface {
+		left = left.Elem()
+	}
+	for right.Kind() == reflect.Ptr || right.Kind() == reflect.Interface {
+		right = right.Elem()
+	}
+
+	leftTime, isLeftTime := left.Interface().(time.Time)
+	rightTime, isRightTime := right.Interface().(time.Time)
+	if isLeftTime && isRightTime {
+		return leftTime.Compare(rightTime)
+	}
+
+	if left.Kind() == right.Kind() {
+		switch left.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(left.Int(), right.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return cmp.Compare(left.Uint(), right.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(left.Float(), right.Float())
+		}
+	}
+
+	leftString, _ := formatFieldValue(left)
+	rightString, _ := formatFieldValue(right)
+	return strings.Compare(leftString, rightString)
+}
@@ -0,0 +1,154 @@
+package core_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ResultListOptions(t *testing.T) {
+	type PublicIP struct {
+		Address string `json:"address"`
+	}
+
+	type Server struct {
+		ID           string     `json:"id"`
+		Name         string     `json:"name"`
+		State        string     `json:"state"`
+		Tags         []string   `json:"tags"`
+		PublicIP     *PublicIP  `json:"public_ip"`
+		CreationDate *time.Time `json:"creation_date"`
+	}
+
+	date := func(day int) *time.Time {
+		d := time.Date(2024, time.January, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	created := false
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace: "list",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return []*Server{
+					{ID: "111111111-111111111", Name: "web", State: "running", Tags: []string{"production", "web"}, PublicIP: &PublicIP{Address: "51.15.0.1"}, CreationDate: date(2)},
+					{ID: "222222222-222222222", Name: "db", State: "stopped", Tags: []string{"production"}, CreationDate: date(3)},
+					{ID: "333333333-333333333", Name: "worker", State: "running", Tags: []string{"staging"}, PublicIP: &PublicIP{Address: "51.15.0.3"}, CreationDate: date(1)},
+				}, nil
+			},
+		},
+		&core.Command{
+			Namespace: "list-empty",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return core.MultiResults(nil), nil
+			},
+		},
+		&core.Command{
+			Namespace: "get",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return &Server{ID: "111111111-111111111", Name: "web", State: "running"}, nil
+			},
+		},
+		&core.Command{
+			Namespace: "create",
+			ArgsType:  reflect.TypeOf(struct{}{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				created = true
+				return &Server{ID: "111111111-111111111", Name: "web", State: "running"}, nil
+			},
+		},
+	)
+
+	t.Run("filter", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--filter", "state=running,tags~prod"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("filter-not-equal", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--filter", "state!=running"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("filter-nested-field", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--filter", "public_ip.address~51.15"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("sort-by", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--sort-by", "-creation_date"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("sort-by-multiple-keys", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--sort-by", "state,name"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("filter-and-sort-by", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "-o", "json", "--filter", "state=running", "--sort-by", "name"},
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("invalid-filter", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--filter", "running"},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+	}))
+
+	t.Run("unknown-field", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list", "--sort-by", "unknown"},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+	}))
+
+	t.Run("empty-untyped-list", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "list-empty", "--filter", "state=running", "--sort-by", "name"},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGolden(),
+		),
+	}))
+
+	t.Run("not-a-list", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "get", "--filter", "state=running"},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+	}))
+
+	// The command is rejected before it runs.
+	t.Run("not-a-list-create", core.Test(&core.TestConfig{
+		Commands: commands,
+		Args:     []string{"scw", "create", "--filter", "state=running"},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+			func(t *testing.T, _ *core.CheckFuncCtx) {
+				t.Helper()
+				assert.False(t, created)
+			},
+		),
+	}))
+}
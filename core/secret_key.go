@@ -0,0 +1,75 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/pkg/shlex"
+	"github.com/scaleway/scaleway-cli/v2/internal/profiles"
+	"github.com/scaleway/scaleway-sdk-go/validation"
+)
+
+// SecretKeyFromCommand returns the secret key of a profile from the standard output of its secret_key_command.
+// The command is run with ExecCmd, its standard error is printed so that it can ask for a passphrase.
+// Its output can be cached for the lifetime of the process, e.g. in shell mode, see the secret_key_cache key.
+// It is never written to disk.
+func SecretKeyFromCommand(ctx context.Context, profileName string, extension *profiles.Extension) (string, error) {
+	command := extension.SecretKeyCommand
+	processCache := extractMeta(ctx).secretKeys
+	switch extension.SecretKeyCache {
+	case "":
+	case profiles.SecretKeyCacheProcess:
+		if key, cached := processCache.Load(command); cached {
+			return key.(string), nil
+		}
+	default:
+		return "", InvalidSecretKeyCacheError(extension.SecretKeyCache)
+	}
+
+	key, err := runSecretKeyCommand(ctx, profileName, command)
+	if err != nil {
+		return "", err
+	}
+
+	if extension.SecretKeyCache == profiles.SecretKeyCacheProcess {
+		processCache.Store(command, key)
+	}
+
+	return key, nil
+}
+
+// runSecretKeyCommand runs a secret_key_command and returns its trimmed standard output.
+// The output is never printed in errors as it may be a valid secret key of another format.
+func runSecretKeyCommand(ctx context.Context, profileName string, command string) (string, error) {
+	words, err := shlex.Split(command)
+	if err != nil || len(words) == 0 {
+		return "", InvalidSecretKeyCommandError(command)
+	}
+
+	stdout := &bytes.Buffer{}
+	cmd := exec.Command(words[0], words[1:]...) //nolint:gosec
+	cmd.Stdout = stdout
+
+	exitCode, err := ExecCmd(ctx, cmd)
+	if err != nil {
+		return "", fmt.Errorf("secret_key_command of profile %s failed: %w", profileName, err)
+	}
+	if exitCode != 0 {
+		return "", &CliError{
+			Err:  fmt.Errorf("secret_key_command of profile %s failed with exit code %d", profileName, exitCode),
+			Hint: "The command must print the secret key of the profile and exit with code 0",
+		}
+	}
+
+	key := strings.TrimSpace(stdout.String())
+	if !validation.IsSecretKey(key) {
+		return "", &CliError{
+			Err:  fmt.Errorf("secret_key_command of profile %s did not print a valid secret key", profileName),
+			Hint: "secret_key should be a valid UUID, formatted as: XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX.",
+		}
+	}
+	return key, nil
+}
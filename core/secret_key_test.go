@@ -0,0 +1,219 @@
+package core_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/platform/terminal"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type secretKeyArgs struct{}
+
+func Test_SecretKeyCommand(t *testing.T) {
+	// The client is created twice, as in shell mode, to show when the command is run.
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "secret-key",
+			Verb:                 "get",
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(secretKeyArgs{}),
+			Run: func(ctx context.Context, _ interface{}) (interface{}, error) {
+				for range 2 {
+					err := core.ReloadClient(ctx)
+					if err != nil {
+						return nil, err
+					}
+				}
+				secretKey, _ := core.ExtractClient(ctx).GetSecretKey()
+				return secretKey, nil
+			},
+		},
+	)
+
+	writeConfig := func(ctx *core.BeforeFuncCtx) error {
+		configDir := filepath.Join(ctx.OverrideEnv["HOME"], ".config", "scw")
+		err := os.MkdirAll(configDir, 0o700)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(`access_key: SCWXXXXXXXXXXXXXXXXX
+default_organization_id: 11111111-1111-1111-1111-111111111111
+default_region: fr-par
+default_zone: fr-par-1
+profiles:
+  prod:
+    secret_key_command: pass show scw/prod
+  staging:
+    extends: prod
+  process:
+    secret_key_command: pass show scw/process
+    secret_key_cache: process
+  session:
+    secret_key_command: pass show scw/session
+    secret_key_cache: session
+  failing:
+    secret_key_command: pass show scw/unknown
+  invalid:
+    secret_key_command: echo not-a-secret-key
+`), 0o600)
+	}
+
+	// pass prints the secret key of known entries, echo prints its arguments.
+	overrideExec := func(_ *core.ExecFuncCtx, cmd *exec.Cmd) (int, error) {
+		_, err := fmt.Fprintf(cmd.Stderr, "ran %s\n", strings.Join(cmd.Args, " "))
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case cmd.Args[0] == "echo":
+			_, err = fmt.Fprintln(cmd.Stdout, strings.Join(cmd.Args[1:], " "))
+		case cmd.Args[2] == "scw/unknown":
+			return 1, nil
+		default:
+			_, err = fmt.Fprintln(cmd.Stdout, "22222222-2222-2222-2222-222222222222")
+		}
+		return 0, err
+	}
+
+	for _, profile := range []string{"prod", "staging", "process", "session", "failing", "invalid"} {
+		t.Run(profile, core.Test(&core.TestConfig{
+			Commands:     commands,
+			TmpHomeDir:   true,
+			BeforeFunc:   writeConfig,
+			OverrideExec: overrideExec,
+			Cmd:          "scw -p " + profile + " test secret-key get",
+			Check:        core.TestCheckGolden(),
+		}))
+	}
+
+	// The key cached by the previous command is not kept on disk, the command is run again.
+	t.Run("process not on disk", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: core.BeforeFuncCombine(
+			writeConfig,
+			core.ExecBeforeCmd("scw -p process test secret-key get"),
+		),
+		OverrideExec: overrideExec,
+		Cmd:          "scw -p process test secret-key get",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			func(t *testing.T, ctx *core.CheckFuncCtx) {
+				t.Helper()
+				for _, dir := range []string{ctx.OverrideEnv["HOME"], ctx.OverrideEnv[scw.ScwCacheDirEnv]} {
+					err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+						if err != nil || entry.IsDir() {
+							return err
+						}
+						content, err := os.ReadFile(path)
+						if err != nil {
+							return err
+						}
+						assert.NotContains(t, string(content), "22222222-2222-2222-2222-222222222222", path)
+						return nil
+					})
+					require.NoError(t, err)
+				}
+			},
+		),
+	}))
+}
+
+func Test_SecretKeyCommandLazy(t *testing.T) {
+	// The client is not given to the CLI, it creates the client of the profile and runs its secret_key_command
+	// only for commands that use it, e.g. not for completions.
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace: "test",
+			Resource:  "secret-key",
+			Verb:      "unused",
+			ArgsType:  reflect.TypeOf(secretKeyArgs{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return "no request sent", nil
+			},
+		},
+		&core.Command{
+			Namespace: "test",
+			Resource:  "secret-key",
+			Verb:      "used",
+			ArgsType:  reflect.TypeOf(secretKeyArgs{}),
+			Run: func(ctx context.Context, _ interface{}) (interface{}, error) {
+				secretKey, _ := core.ExtractClient(ctx).GetSecretKey()
+				return secretKey, nil
+			},
+		},
+	)
+
+	homeDir := t.TempDir()
+	configDir := filepath.Join(homeDir, ".config", "scw")
+	require.NoError(t, os.MkdirAll(configDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(`access_key: SCWXXXXXXXXXXXXXXXXX
+default_organization_id: 11111111-1111-1111-1111-111111111111
+default_region: fr-par
+default_zone: fr-par-1
+secret_key_command: pass show scw/default
+`), 0o600))
+
+	run := func(t *testing.T, args ...string) (stdout string, stderr string) {
+		t.Helper()
+		stdoutBuffer := &bytes.Buffer{}
+		stderrBuffer := &bytes.Buffer{}
+		buildInfo := &core.BuildInfo{
+			Version:         version.Must(version.NewSemver("v0.0.0+test")),
+			UserAgentPrefix: "scaleway-cli",
+		}
+		exitCode, _, err := core.Bootstrap(&core.BootstrapConfig{
+			Args:             append([]string{"scw"}, args...),
+			Commands:         commands.Copy(),
+			BuildInfo:        buildInfo,
+			Stdout:           stdoutBuffer,
+			Stderr:           stderrBuffer,
+			DisableTelemetry: true,
+			DisableAliases:   true,
+			DisableHistory:   true,
+			OverrideEnv: map[string]string{
+				"HOME":             homeDir,
+				scw.ScwCacheDirEnv: filepath.Join(homeDir, ".cache", "scw"),
+			},
+			OverrideExec: func(cmd *exec.Cmd) (int, error) {
+				_, err := fmt.Fprintf(cmd.Stderr, "ran %s\n", strings.Join(cmd.Args, " "))
+				if err != nil {
+					return 0, err
+				}
+				_, err = fmt.Fprintln(cmd.Stdout, "22222222-2222-2222-2222-222222222222")
+				return 0, err
+			},
+			Ctx:      context.Background(),
+			Platform: terminal.NewPlatform(buildInfo.GetUserAgent()),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 0, exitCode, "stdout: %s\nstderr: %s", stdoutBuffer.String(), stderrBuffer.String())
+		return stdoutBuffer.String(), stderrBuffer.String()
+	}
+
+	t.Run("client unused", func(t *testing.T) {
+		stdout, stderr := run(t, "test", "secret-key", "unused")
+		assert.Contains(t, stdout, "no request sent")
+		assert.NotContains(t, stderr, "ran pass")
+	})
+
+	t.Run("client used", func(t *testing.T) {
+		stdout, stderr := run(t, "test", "secret-key", "used")
+		assert.Contains(t, stdout, "22222222-2222-2222-2222-222222222222")
+		assert.Contains(t, stderr, "ran pass show scw/default")
+	})
+}
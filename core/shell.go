@@ -13,7 +13,6 @@ import (
 
 	"github.com/c-bata/go-prompt"
 	"github.com/fatih/color"
-	"github.com/scaleway/scaleway-cli/v2/internal/cache"
 	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
 	"github.com/scaleway/scaleway-cli/v2/internal/sentry"
 	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
@@ -135,7 +134,7 @@ func getCommand(meta *Meta, args []string, suggest string) *Command {
 		rawCommand = append(rawCommand, suggest)
 	}
 
-	rawCommand = meta.CliConfig.Alias.ResolveAliases(rawCommand)
+	rawCommand = meta.aliases.ResolveAliases(rawCommand)
 
 	// Find the closest command in case there is multiple positional arguments
 	for ; len(rawCommand) > 1; rawCommand = rawCommand[:len(rawCommand)-1] {
@@ -217,18 +216,20 @@ func (c *Completer) Complete(d prompt.Document) []prompt.Suggest {
 
 	meta := extractMeta(c.ctx)
 
-	argsBeforeCursor := meta.CliConfig.Alias.ResolveAliases(strings.Split(d.TextBeforeCursor(), " "))
-	argsAfterCursor := meta.CliConfig.Alias.ResolveAliases(strings.Split(d.TextAfterCursor(), " "))
-	currentArg := lastArg(argsBeforeCursor) + firstArg(argsAfterCursor)
+	wordsBeforeCursor := strings.Split(d.TextBeforeCursor(), " ")
+	wordsAfterCursor := strings.Split(d.TextAfterCursor(), " ")
+	// The current word is not resolved as it could be used by the placeholders of an alias.
+	currentArg := lastArg(wordsBeforeCursor) + firstArg(wordsAfterCursor)
 
 	// leftArgs contains all arguments before the one with the cursor
-	leftArgs := trimLastArg(argsBeforeCursor)
+	leftArgs := meta.aliases.ResolveAliases(trimLastArg(wordsBeforeCursor))
 	// rightWords contains all words after the selected one
-	rightWords := trimFirstArg(argsAfterCursor)
+	rightWords := meta.aliases.ResolveAliases(trimFirstArg(wordsAfterCursor))
 
 	leftWords := append([]string{"scw"}, leftArgs...)
 
 	acr := AutoComplete(c.ctx, leftWords, currentArg, rightWords)
+	acr.Suggestions = append(acr.Suggestions, meta.aliases.CompletePlaceholders(trimLastArg(wordsBeforeCursor), currentArg)...)
 
 	suggestions := []prompt.Suggest(nil)
 	rawSuggestions := []string(acr.Suggestions)
@@ -262,7 +263,7 @@ func shellExecutor(rootCmd *cobra.Command, printer *Printer, meta *Meta) func(s
 
 		sentry.AddCommandContext(strings.Join(removeOptions(args), " "))
 
-		rootCmd.SetArgs(meta.CliConfig.Alias.ResolveAliases(args))
+		rootCmd.SetArgs(meta.aliases.ResolveAliases(args))
 
 		err := rootCmd.Execute()
 		if err != nil {
@@ -284,8 +285,6 @@ func shellExecutor(rootCmd *cobra.Command, printer *Printer, meta *Meta) func(s
 			return
 		}
 
-		autoCompleteCache.Update(meta.command.Namespace)
-
 		printErr := printer.Print(meta.result, meta.command.getHumanMarshalerOpt())
 		if printErr != nil {
 			_, _ = fmt.Fprintln(os.Stderr, printErr)
@@ -306,7 +305,6 @@ func getShellCommand(rootCmd *cobra.Command) *cobra.Command {
 
 // RunShell will run an interactive shell that runs cobra commands
 func RunShell(ctx context.Context, printer *Printer, meta *Meta, rootCmd *cobra.Command, args []string) {
-	autoCompleteCache = cache.New()
 	completer := NewShellCompleter(ctx)
 
 	shellCobraCommand := getShellCommand(rootCmd)
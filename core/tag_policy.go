@@ -0,0 +1,192 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	cliConfig "github.com/scaleway/scaleway-cli/v2/internal/config"
+)
+
+// tagPolicyTemplateData is the data of the templates filling missing tags.
+type tagPolicyTemplateData struct {
+	Profile   string
+	Namespace string
+	Resource  string
+}
+
+// tagPolicyInterceptor checks the tags of create commands against the tag policies of the CLI config before running them.
+// Tags are key=value strings, missing required tags are filled from the template of the policy when it has one.
+// Nested tags, e.g. the tags of the pools of a cluster, are checked for each resource. Commands with tags that are not
+// key=value strings fail, as their tags cannot be checked.
+func tagPolicyInterceptor(ctx context.Context, argsI interface{}, runner CommandRunner) (interface{}, error) {
+	meta := extractMeta(ctx)
+	if meta.command == nil || meta.CliConfig == nil || meta.command.Verb != "create" {
+		return runner(ctx, argsI)
+	}
+	policies := tagPoliciesOf(meta.command, meta.CliConfig.TagPolicies)
+	if len(policies) == 0 {
+		return runner(ctx, argsI)
+	}
+
+	for _, argSpec := range meta.command.ArgSpecs {
+		if !isTagArgSpec(argSpec) {
+			continue
+		}
+		fieldPath, isSlice := strings.CutSuffix(argSpec.Name, "."+sliceSchema)
+		values, err := GetValuesForFieldByName(reflect.ValueOf(argsI), strings.Split(fieldPath, "."))
+		if err != nil || !isSlice {
+			return nil, uncheckedTagsError(argSpec.Name)
+		}
+
+		for _, tagsValue := range values {
+			if tagsValue.Type() != reflect.TypeOf([]string(nil)) {
+				return nil, uncheckedTagsError(argSpec.Name)
+			}
+			tags, err := applyTagPolicies(ctx, meta.command, policies, argSpec.Name, tagsValue.Interface().([]string))
+			if err != nil {
+				return nil, err
+			}
+			tagsValue.Set(reflect.ValueOf(tags))
+		}
+	}
+
+	return runner(ctx, argsI)
+}
+
+// tagPoliciesOf returns the policies applying to the namespace of the command.
+func tagPoliciesOf(cmd *Command, policies []*cliConfig.TagPolicyConfig) []*cliConfig.TagPolicyConfig {
+	commandPolicies := []*cliConfig.TagPolicyConfig(nil)
+	for _, policy := range policies {
+		if policy == nil || (len(policy.Namespaces) > 0 && !slices.Contains(policy.Namespaces, cmd.Namespace)) {
+			continue
+		}
+		commandPolicies = append(commandPolicies, policy)
+	}
+	return commandPolicies
+}
+
+// isTagArgSpec returns true for the arguments holding tags, e.g. tags.{index} or pools.{index}.tags.{index}.
+func isTagArgSpec(argSpec *ArgSpec) bool {
+	for _, part := range strings.Split(argSpec.Name, ".") {
+		if part == "tags" {
+			return true
+		}
+	}
+	return false
+}
+
+// uncheckedTagsError is returned when the tags of a command cannot be checked against the policies applying to it.
+func uncheckedTagsError(argName string) *CliError {
+	return &CliError{
+		Err:  fmt.Errorf("argument '%s' cannot be checked against the tag policy, tags must be key=value strings", argName),
+		Hint: "Tag policies are defined in the tag_policies section of the CLI config, their namespaces select the commands they apply to",
+	}
+}
+
+// applyTagPolicies returns the tags with the missing required tags filled from templates.
+// It returns an error listing the tags of argName breaking the policies, e.g. tags.{index}.
+func applyTagPolicies(ctx context.Context, cmd *Command, policies []*cliConfig.TagPolicyConfig, argName string, tags []string) ([]string, error) {
+	tagValues := map[string]string{}
+	for _, tag := range tags {
+		key, value, _ := strings.Cut(tag, "=")
+		tagValues[key] = value
+	}
+
+	violations := []string(nil)
+	missing := []string(nil)
+	for _, policy := range policies {
+		keys := make([]string, 0, len(policy.Required))
+		for key := range policy.Required {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			valueRegexp, err := regexp.Compile(policy.Required[key])
+			if err != nil {
+				return nil, &CliError{
+					Err:  fmt.Errorf("invalid regular expression of tag '%s' in the tag_policies section of the CLI config: %w", key, err),
+					Hint: "Values of required tags are regular expressions, e.g. ^[0-9]{4}$, an empty one matches any value",
+				}
+			}
+
+			value, exists := tagValues[key]
+			if !exists && policy.Template[key] != "" {
+				value, err = executeTagTemplate(ctx, cmd, key, policy.Template[key])
+				if err != nil {
+					return nil, err
+				}
+				// A template using an unset environment variable does not fill the tag.
+				if value != "" {
+					tags = append(tags, key+"="+value)
+					tagValues[key] = value
+					exists = true
+				}
+			}
+
+			switch {
+			case !exists && slices.Contains(missing, key):
+				// The tag is required by several policies.
+			case !exists:
+				violations = append(violations, fmt.Sprintf("tag '%s' is missing", key))
+				missing = append(missing, key)
+			case !valueRegexp.MatchString(value):
+				violations = append(violations, fmt.Sprintf("value '%s' of tag '%s' does not match '%s'", value, key, valueRegexp.String()))
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		// The index of the tag is the last one of the argument, e.g. pools.{index}.tags.{index}.
+		argPrefix := strings.TrimSuffix(argName, sliceSchema)
+		hint := "Required tags are defined in the tag_policies section of the CLI config"
+		if len(missing) > 0 {
+			example := []string(nil)
+			for i, key := range missing {
+				example = append(example, fmt.Sprintf("%s%d=%s=<value>", argPrefix, len(tags)+i, key))
+			}
+			hint = fmt.Sprintf("Add the missing tags, e.g. %s\n%s", strings.Join(example, " "), hint)
+		}
+		resource := "tags"
+		if parent, isNested := strings.CutSuffix(argPrefix, ".tags."); isNested {
+			resource = "tags of " + parent
+		}
+		return nil, &CliError{
+			Err:  fmt.Errorf("%s do not follow the tag policy: %s", resource, strings.Join(violations, ", ")),
+			Hint: hint,
+		}
+	}
+
+	return tags, nil
+}
+
+// executeTagTemplate returns the value of a missing tag from its template, e.g. {{ env "USER" }}.
+func executeTagTemplate(ctx context.Context, cmd *Command, key string, text string) (string, error) {
+	tmpl, err := template.New(key).Option("missingkey=error").Funcs(template.FuncMap{
+		"env": func(name string) string {
+			return ExtractEnv(ctx, name)
+		},
+	}).Parse(text)
+	if err == nil {
+		buf := bytes.Buffer{}
+		err = tmpl.Execute(&buf, &tagPolicyTemplateData{
+			Profile:   ExtractProfileName(ctx),
+			Namespace: cmd.Namespace,
+			Resource:  cmd.Resource,
+		})
+		if err == nil {
+			return strings.TrimSpace(buf.String()), nil
+		}
+	}
+	return "", &CliError{
+		Err:  fmt.Errorf("invalid template of tag '%s' in the tag_policies section of the CLI config: %w", key, err),
+		Hint: `Templates use the Go template syntax with .Profile, .Namespace, .Resource and env, e.g. {{ env "USER" }}`,
+	}
+}
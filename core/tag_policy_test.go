@@ -0,0 +1,194 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+)
+
+type tagPolicyServerArgs struct {
+	Name string
+	Tags []string
+}
+
+type tagPolicyPool struct {
+	Name string
+	Tags []string
+}
+
+type tagPolicyClusterArgs struct {
+	Tags  []string
+	Pools []*tagPolicyPool
+}
+
+type tagPolicyBucketArgs struct {
+	Tags map[string]string
+}
+
+func Test_TagPolicy(t *testing.T) {
+	createCommand := func(namespace string) *core.Command {
+		return &core.Command{
+			Namespace:            namespace,
+			Resource:             "server",
+			Verb:                 "create",
+			AllowAnonymousClient: true,
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name: "name",
+				},
+				{
+					Name: "tags.{index}",
+				},
+			},
+			ArgsType: reflect.TypeOf(tagPolicyServerArgs{}),
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				return strings.Join(argsI.(*tagPolicyServerArgs).Tags, ", "), nil
+			},
+		}
+	}
+	commands := core.NewCommands(
+		createCommand("test"),
+		createCommand("other"),
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "cluster",
+			Verb:                 "create",
+			AllowAnonymousClient: true,
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name: "tags.{index}",
+				},
+				{
+					Name: "pools.{index}.name",
+				},
+				{
+					Name: "pools.{index}.tags.{index}",
+				},
+			},
+			ArgsType: reflect.TypeOf(tagPolicyClusterArgs{}),
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				return argsI, nil
+			},
+		},
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "bucket",
+			Verb:                 "create",
+			AllowAnonymousClient: true,
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name: "tags.{key}",
+				},
+			},
+			ArgsType: reflect.TypeOf(tagPolicyBucketArgs{}),
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				return argsI, nil
+			},
+		},
+	)
+
+	writeCliConfig := func(ctx *core.BeforeFuncCtx) error {
+		configDir := filepath.Join(ctx.OverrideEnv["HOME"], ".config", "scw")
+		err := os.MkdirAll(configDir, 0o700)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(configDir, "cli.yaml"), []byte(`tag_policies:
+  - namespaces:
+      - test
+    required:
+      cost-center: "^[0-9]{4}$"
+      owner: ""
+    template:
+      owner: '{{ env "USER" }}'
+`), 0o600)
+	}
+
+	// The template of the owner tag does not fill it when USER is not set.
+	t.Run("missing tags", core.Test(&core.TestConfig{
+		Commands:    commands,
+		TmpHomeDir:  true,
+		BeforeFunc:  writeCliConfig,
+		OverrideEnv: map[string]string{"USER": ""},
+		Cmd:         "scw test server create tags.0=web",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	t.Run("invalid value", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: writeCliConfig,
+		Cmd:        "scw test server create tags.0=cost-center=finance tags.1=owner=alice",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	t.Run("template", core.Test(&core.TestConfig{
+		Commands:    commands,
+		TmpHomeDir:  true,
+		BeforeFunc:  writeCliConfig,
+		OverrideEnv: map[string]string{"USER": "bob"},
+		Cmd:         "scw test server create tags.0=cost-center=1234",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("other namespace", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: writeCliConfig,
+		Cmd:        "scw other server create tags.0=web",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	// The tags of each pool are checked too.
+	t.Run("nested tags", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: writeCliConfig,
+		Cmd:        "scw test cluster create tags.0=cost-center=1234 tags.1=owner=alice pools.0.name=default pools.0.tags.0=cost-center=finance pools.0.tags.1=owner=alice",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	t.Run("nested tags template", core.Test(&core.TestConfig{
+		Commands:    commands,
+		TmpHomeDir:  true,
+		BeforeFunc:  writeCliConfig,
+		OverrideEnv: map[string]string{"USER": "bob"},
+		Cmd:         "scw test cluster create tags.0=cost-center=1234 pools.0.name=default pools.0.tags.0=cost-center=5678",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	// Tags that are not key=value strings cannot be checked.
+	t.Run("unchecked tags", core.Test(&core.TestConfig{
+		Commands:   commands,
+		TmpHomeDir: true,
+		BeforeFunc: writeCliConfig,
+		Cmd:        "scw test bucket create tags.cost-center=1234",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+}
@@ -0,0 +1,3 @@
+- name: {{ .Name }}
+  address:
+{{ toYaml (default "unknown" .Address) | indent 4 }}
@@ -180,6 +180,7 @@ type TestConfig struct {
 	// Get this folder with ExtractUserHomeDir()
 	// This will also use this temporary directory as a cache directory.
 	// Get this folder with ExtractCacheDir()
+	// Otherwise, an empty temporary cache directory is used unless OverrideEnv sets SCW_CACHE_DIR.
 	TmpHomeDir bool
 
 	// OverrideEnv contains environment variables that will be overridden during the test.
@@ -343,6 +344,8 @@ func Test(config *TestConfig) func(t *testing.T) {
 			overrideEnv = map[string]string{}
 		}
 
+		// Project config files are looked for from an empty temporary directory, not from the tested package.
+		workDir := t.TempDir()
 		if config.TmpHomeDir {
 			dir, err := os.MkdirTemp(os.TempDir(), "scw")
 			require.NoError(t, err)
@@ -354,6 +357,15 @@ func Test(config *TestConfig) func(t *testing.T) {
 			overrideEnv[scw.ScwCacheDirEnv] = dir
 			meta["HOME"] = dir
 			meta[scw.ScwCacheDirEnv] = dir
+			workDir = dir
+		}
+		// Responses cached by a test, e.g. to autocomplete arguments, are not reused by other tests.
+		if _, exists := overrideEnv[scw.ScwCacheDirEnv]; !exists {
+			overrideEnv[scw.ScwCacheDirEnv] = t.TempDir()
+		}
+		// Plugins are looked for in PATH, tests do not find the ones installed on the machine.
+		if _, exists := overrideEnv["PATH"]; !exists {
+			overrideEnv["PATH"] = t.TempDir()
 		}
 
 		overrideExec := defaultOverrideExec
@@ -390,7 +402,8 @@ func Test(config *TestConfig) func(t *testing.T) {
 			stdoutBuffer := &bytes.Buffer{}
 			stderrBuffer := &bytes.Buffer{}
 			_, result, err := Bootstrap(&BootstrapConfig{
-				Args:             args,
+				// Prompt mocks are meant for the tested command, commands run before and after it are never confirmed.
+				Args:             append(args, "--yes"),
 				Commands:         config.Commands.Copy(), // Copy commands to ensure they are not modified
 				BuildInfo:        buildInfo,
 				Stdout:           stdoutBuffer,
@@ -398,12 +411,14 @@ func Test(config *TestConfig) func(t *testing.T) {
 				Client:           client,
 				DisableTelemetry: true,
 				DisableAliases:   !config.EnableAliases,
+				DisableHistory:   !config.TmpHomeDir,
 				OverrideEnv:      overrideEnv,
 				OverrideExec:     overrideExec,
 				Ctx:              ctx,
 				Logger:           testLogger,
 				HTTPClient:       httpClient,
 				Platform:         terminal.NewPlatform(buildInfo.GetUserAgent()),
+				WorkDir:          workDir,
 			})
 			require.NoError(t, err, "error executing cmd (%s)\nstdout: %s\nstderr: %s", args, stdoutBuffer.String(), stderrBuffer.String())
 
@@ -459,12 +474,14 @@ func Test(config *TestConfig) func(t *testing.T) {
 				Client:           client,
 				DisableTelemetry: true,
 				DisableAliases:   !config.EnableAliases,
+				DisableHistory:   !config.TmpHomeDir,
 				OverrideEnv:      overrideEnv,
 				OverrideExec:     overrideExec,
 				Ctx:              ctx,
 				Logger:           cmdLogger,
 				HTTPClient:       httpClient,
 				Platform:         terminal.NewPlatform(buildInfo.GetUserAgent()),
+				WorkDir:          workDir,
 			})
 
 			meta["CmdResult"] = result
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TimeoutExitCode is the exit code of a command stopped by the --timeout flag.
+// It is the exit code of the timeout coreutils command.
+const TimeoutExitCode = 124
+
+// minWaitTimeout is given to wait helpers once the deadline is passed, as a zero timeout means the default one.
+const minWaitTimeout = time.Millisecond
+
+// WaitTimeout returns the timeout of a wait helper.
+// When the --timeout flag is set, the remaining time before its deadline replaces timeout, e.g. the default timeout of
+// the helper, so that the flag can lengthen waits. Only a timeout argument given to the command, e.g. timeout=5m,
+// narrows the deadline further. A nil timeout keeps the default timeout of the SDK wait helper.
+// The flag only bounds waits, API requests sent by the SDK are not bound to the context.
+func WaitTimeout(ctx context.Context, timeout *time.Duration) *time.Duration {
+	deadline, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		return timeout
+	}
+
+	remaining := time.Until(deadline)
+	meta, ok := ctx.Value(metaContextKey).(*Meta)
+	if ok && meta.timeoutArg && timeout != nil && *timeout < remaining {
+		return timeout
+	}
+	if remaining < minWaitTimeout {
+		remaining = minWaitTimeout
+	}
+	return &remaining
+}
+
+// WaitRetryInterval returns the interval between two polls of a wait helper.
+// It is set by the --wait-interval flag, DefaultRetryInterval is used otherwise.
+func WaitRetryInterval(ctx context.Context) *time.Duration {
+	meta, ok := ctx.Value(metaContextKey).(*Meta)
+	if ok && meta.waitInterval > 0 {
+		interval := meta.waitInterval
+		return &interval
+	}
+	return DefaultRetryInterval
+}
+
+// withCommandTimeout sets the deadline of the --timeout flag on the context of a command.
+func withCommandTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := extractMeta(ctx).timeout
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// timeoutError replaces the error of a command that ran past the deadline of the --timeout flag.
+// Wait helpers stop at the deadline with their own error, the context tells whether the deadline was hit.
+func timeoutError(ctx context.Context, err error) error {
+	deadline, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		return err
+	}
+	if !errors.Is(err, context.DeadlineExceeded) && time.Now().Before(deadline) {
+		return err
+	}
+
+	return &CliError{
+		Err:     errors.New("command timed out"),
+		Details: err.Error(),
+		Hint:    "Increase --timeout, the resource may still be in a transient state",
+		Code:    TimeoutExitCode,
+	}
+}
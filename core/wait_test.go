@@ -0,0 +1,124 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+type waitFlowerRequest struct{}
+
+type growFlowerRequest struct {
+	Timeout time.Duration
+}
+
+func Test_Timeout(t *testing.T) {
+	commands := core.NewCommands(
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "flower",
+			Verb:                 "create",
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(waitFlowerRequest{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return "created", nil
+			},
+			WaitFunc: func(ctx context.Context, _, _ interface{}) (interface{}, error) {
+				// Behaves like the wait helpers of the SDK, which return their own error on timeout.
+				timeout := core.WaitTimeout(ctx, scw.TimeDurationPtr(time.Hour))
+				if *timeout > time.Minute {
+					return fmt.Sprintf("bloomed, checked every %s", core.WaitRetryInterval(ctx)), nil
+				}
+				<-time.After(*timeout)
+				return nil, errors.New("waiting for flower failed: timeout")
+			},
+		},
+		&core.Command{
+			Namespace:            "test",
+			Resource:             "flower",
+			Verb:                 "grow",
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(growFlowerRequest{}),
+			ArgSpecs: core.ArgSpecs{
+				core.WaitTimeoutArgSpec(time.Millisecond),
+			},
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return "growing", nil
+			},
+			WaitFunc: func(ctx context.Context, argsI, _ interface{}) (interface{}, error) {
+				timeout := core.WaitTimeout(ctx, scw.TimeDurationPtr(argsI.(*growFlowerRequest).Timeout))
+				if *timeout > time.Minute {
+					return "grown", nil
+				}
+				return nil, fmt.Errorf("waiting for flower failed: timeout after %s", *timeout)
+			},
+		},
+	)
+
+	t.Run("wait timeout", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower create --wait --timeout 10ms",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(core.TimeoutExitCode),
+		),
+	}))
+
+	t.Run("wait interval", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower create --wait --wait-interval 5s",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	// The deadline of --timeout replaces the default timeout of the wait, even when it is longer.
+	t.Run("timeout longer than default", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower grow --wait --timeout 1h",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("timeout arg shorter than timeout", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower grow timeout=1s --wait --timeout 1h",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	t.Run("negative timeout", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      "scw test flower create --timeout -1s",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+}
+
+func Test_WaitTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// The remaining time before the deadline replaces the default timeouts, shorter or longer.
+	assert.Greater(t, *core.WaitTimeout(ctx, scw.TimeDurationPtr(10*time.Second)), 10*time.Second)
+	assert.LessOrEqual(t, *core.WaitTimeout(ctx, scw.TimeDurationPtr(time.Hour)), time.Minute)
+	assert.LessOrEqual(t, *core.WaitTimeout(ctx, nil), time.Minute)
+
+	// Without --timeout, the timeout is kept.
+	assert.Equal(t, time.Hour, *core.WaitTimeout(context.Background(), scw.TimeDurationPtr(time.Hour)))
+	assert.Nil(t, core.WaitTimeout(context.Background(), nil))
+}
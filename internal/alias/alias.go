@@ -1,6 +1,15 @@
 package alias
 
-import "strings"
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// placeholderRegexp matches the placeholders of an alias command: $1, $@, ${name} and ${name:-default}.
+var placeholderRegexp = regexp.MustCompile(`\$(?:([1-9][0-9]*)|(@)|\{([a-zA-Z][a-zA-Z0-9_-]*)(:-[^}]*)?\})`)
 
 type Alias struct {
 	// alias' key
@@ -12,6 +21,43 @@ type Alias struct {
 	args []string
 }
 
+// Placeholder is a part of an alias command replaced by the words following the alias when it is used.
+type Placeholder struct {
+	// Position is the position of the word replacing $1, $2... 0 for other placeholders
+	Position int
+	// All is true for $@, replaced by the words not used by other positional placeholders
+	All bool
+	// Name is the name of ${name} placeholders, replaced by the value of a name=value word
+	Name string
+	// Default is the value of a ${name:-default} placeholder when no name=value word is given
+	Default string
+	// HasDefault is true if the placeholder has a default value, even empty
+	HasDefault bool
+}
+
+func (p *Placeholder) String() string {
+	switch {
+	case p.Position > 0:
+		return fmt.Sprintf("<$%d>", p.Position)
+	case p.All:
+		return "[$@...]"
+	case p.HasDefault:
+		return fmt.Sprintf("[%s=%s]", p.Name, p.Default)
+	}
+	return p.Name + "=<value>"
+}
+
+func parsePlaceholder(match []string) Placeholder {
+	position, _ := strconv.Atoi(match[1])
+	return Placeholder{
+		Position:   position,
+		All:        match[2] != "",
+		Name:       match[3],
+		Default:    strings.TrimPrefix(match[4], ":-"),
+		HasDefault: match[4] != "",
+	}
+}
+
 func (a *Alias) computeArgs() {
 	a.args = []string{}
 	for _, cmd := range a.Command {
@@ -28,3 +74,153 @@ func (a *Alias) Args() []string {
 	}
 	return a.args
 }
+
+// Placeholders returns the placeholders of the alias command, positional ones first.
+// A placeholder used several times is returned once.
+func (a *Alias) Placeholders() []Placeholder {
+	placeholders := []Placeholder(nil)
+	seen := map[string]bool{}
+	for _, word := range a.Command {
+		for _, match := range placeholderRegexp.FindAllStringSubmatch(word, -1) {
+			placeholder := parsePlaceholder(match)
+			key := match[1] + match[2] + match[3]
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			placeholders = append(placeholders, placeholder)
+		}
+	}
+	sort.SliceStable(placeholders, func(i, j int) bool {
+		return placeholderOrder(placeholders[i]) < placeholderOrder(placeholders[j])
+	})
+	return placeholders
+}
+
+// placeholderOrder sorts positional placeholders by position, then $@, then named placeholders.
+func placeholderOrder(p Placeholder) int {
+	switch {
+	case p.Position > 0:
+		return p.Position
+	case p.All:
+		return 1 << 30
+	}
+	return 1<<30 + 1
+}
+
+// HasPlaceholders returns true if the alias command uses placeholders.
+func (a *Alias) HasPlaceholders() bool {
+	for _, word := range a.Command {
+		if placeholderRegexp.MatchString(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Usage returns the arguments expected by the alias, e.g. <$1> [user=admin].
+func (a *Alias) Usage() string {
+	usage := []string(nil)
+	for _, placeholder := range a.Placeholders() {
+		usage = append(usage, placeholder.String())
+	}
+	return strings.Join(usage, " ")
+}
+
+// Validate returns an error if the alias command contains a malformed placeholder, e.g. ${name or $0.
+func (a *Alias) Validate() error {
+	for _, word := range a.Command {
+		rest := placeholderRegexp.ReplaceAllString(word, "")
+		if strings.Contains(rest, "${") || strings.Contains(rest, "$0") {
+			return fmt.Errorf("invalid placeholder in '%s', placeholders are $1, $2..., $@, ${name} and ${name:-default}", word)
+		}
+	}
+	return nil
+}
+
+// Expand returns the alias command with its placeholders replaced by the given words, which follow the alias.
+// Words are used until the first flag: name=value words set named placeholders, others set $1, $2... in order.
+// $@ is replaced by the words not used by $1, $2..., a word made only of a placeholder without value is removed.
+// The words not used by placeholders are returned to be added after the command.
+func (a *Alias) Expand(words []string) ([]string, []string) {
+	if !a.HasPlaceholders() {
+		return a.Command, words
+	}
+
+	placeholders := a.Placeholders()
+	names := map[string]bool{}
+	positionalCount := 0
+	all := false
+	for _, placeholder := range placeholders {
+		switch {
+		case placeholder.Position > positionalCount:
+			positionalCount = placeholder.Position
+		case placeholder.All:
+			all = true
+		case placeholder.Name != "":
+			names[placeholder.Name] = true
+		}
+	}
+
+	flagIndex := len(words)
+	for i, word := range words {
+		if strings.HasPrefix(word, "-") {
+			flagIndex = i
+			break
+		}
+	}
+
+	values := map[string]string{}
+	positional := []string(nil)
+	for _, word := range words[:flagIndex] {
+		if name, value, isArg := strings.Cut(word, "="); isArg && names[name] {
+			values[name] = value
+			continue
+		}
+		positional = append(positional, word)
+	}
+
+	rest := []string(nil)
+	remaining := []string(nil)
+	if len(positional) > positionalCount {
+		remaining = positional[positionalCount:]
+	}
+	if !all {
+		rest = remaining
+	}
+	rest = append(rest, words[flagIndex:]...)
+
+	command := make([]string, 0, len(a.Command))
+	for _, word := range a.Command {
+		if word == "$@" {
+			command = append(command, remaining...)
+			continue
+		}
+
+		set := false
+		expanded := placeholderRegexp.ReplaceAllStringFunc(word, func(match string) string {
+			placeholder := parsePlaceholder(placeholderRegexp.FindStringSubmatch(match))
+			value, exists := "", false
+			switch {
+			case placeholder.Position > 0 && placeholder.Position <= len(positional):
+				value, exists = positional[placeholder.Position-1], true
+			case placeholder.All:
+				value, exists = strings.Join(remaining, " "), len(remaining) > 0
+			case placeholder.Name != "":
+				value, exists = values[placeholder.Name]
+				if !exists && placeholder.HasDefault {
+					value, exists = placeholder.Default, true
+				}
+			}
+			set = set || (exists && value != "")
+			return value
+		})
+
+		if !set && placeholderRegexp.ReplaceAllString(word, "") == "" {
+			continue
+		}
+		command = append(command, expanded)
+	}
+
+	return command, rest
+}
@@ -1,5 +1,7 @@
 package alias
 
+import "strings"
+
 type Config struct {
 	// Aliases are raw aliases that allow to expand a command
 	// "scw instance sl", sl may be an alias and would expand command
@@ -32,18 +34,60 @@ func (c *Config) GetAlias(name string) []string {
 
 // ResolveAliases resolve aliases in given command
 // "scw isl" may return "scw instance server list"
+// The placeholders of an alias are replaced by the words following it, see Alias.Expand
 func (c *Config) ResolveAliases(command []string) []string {
 	expandedCommand := make([]string, 0, len(command))
-	for _, arg := range command {
-		if alias := c.GetAlias(arg); alias != nil {
-			expandedCommand = append(expandedCommand, alias...)
-		} else {
+	for i, arg := range command {
+		alias := c.GetAlias(arg)
+		switch {
+		case alias == nil:
 			expandedCommand = append(expandedCommand, arg)
+		case (&Alias{Name: arg, Command: alias}).HasPlaceholders():
+			expanded, rest := (&Alias{Name: arg, Command: alias}).Expand(command[i+1:])
+			expandedCommand = append(expandedCommand, expanded...)
+			return append(expandedCommand, c.ResolveAliases(rest)...)
+		default:
+			expandedCommand = append(expandedCommand, alias...)
 		}
 	}
 	return expandedCommand
 }
 
+// CompletePlaceholders returns the name= suggestions of the named placeholders of the last alias in words
+// that match wordToComplete and are not set yet.
+// words are the words before wordToComplete, before resolving aliases
+func (c *Config) CompletePlaceholders(words []string, wordToComplete string) []string {
+	if strings.Contains(wordToComplete, "=") || strings.HasPrefix(wordToComplete, "-") {
+		return nil
+	}
+
+	for i := len(words) - 1; i >= 0; i-- {
+		if strings.HasPrefix(words[i], "-") {
+			return nil
+		}
+		command := c.GetAlias(words[i])
+		if command == nil {
+			continue
+		}
+
+		suggestions := []string(nil)
+		for _, placeholder := range (&Alias{Name: words[i], Command: command}).Placeholders() {
+			if placeholder.Name == "" || !strings.HasPrefix(placeholder.Name, wordToComplete) {
+				continue
+			}
+			set := false
+			for _, word := range words[i+1:] {
+				set = set || strings.HasPrefix(word, placeholder.Name+"=")
+			}
+			if !set {
+				suggestions = append(suggestions, placeholder.Name+"=")
+			}
+		}
+		return suggestions
+	}
+	return nil
+}
+
 // AddAlias add alias to config
 // return true if alias has been replaced
 func (c *Config) AddAlias(name string, command []string) bool {
@@ -35,6 +35,35 @@ func TestConfig_ResolveAliases(t *testing.T) {
 			Command:  []string{"instance", "sl", "zone=fr-par-1"},
 			Expected: []string{"instance", "server", "list", "zone=fr-par-1"},
 		},
+		{
+			Aliases: map[string][]string{
+				"ssh-prod": {"instance", "server", "ssh", "$1", "username=${user:-admin}", "zone=nl-ams-1"},
+			},
+			Command:  []string{"scw", "ssh-prod", "11111111-1111-1111-1111-111111111111", "port=2222"},
+			Expected: []string{"scw", "instance", "server", "ssh", "11111111-1111-1111-1111-111111111111", "username=admin", "zone=nl-ams-1", "port=2222"},
+		},
+		{
+			Aliases: map[string][]string{
+				"ssh-prod": {"instance", "server", "ssh", "$1", "username=${user:-admin}", "zone=nl-ams-1"},
+			},
+			Command:  []string{"ssh-prod", "user=root", "11111111-1111-1111-1111-111111111111", "-o", "json"},
+			Expected: []string{"instance", "server", "ssh", "11111111-1111-1111-1111-111111111111", "username=root", "zone=nl-ams-1", "-o", "json"},
+		},
+		{
+			Aliases: map[string][]string{
+				"tagged": {"instance", "server", "list", "tags.0=env=$1", "$@"},
+				"sl":     {"server", "list"},
+			},
+			Command:  []string{"tagged", "prod", "zone=fr-par-1", "name=web", "-o", "sl"},
+			Expected: []string{"instance", "server", "list", "tags.0=env=prod", "zone=fr-par-1", "name=web", "-o", "server", "list"},
+		},
+		{
+			Aliases: map[string][]string{
+				"get": {"instance", "server", "get", "$1", "${zone}"},
+			},
+			Command:  []string{"get"},
+			Expected: []string{"instance", "server", "get"},
+		},
 	}
 	for i, tt := range tests {
 		t.Run(fmt.Sprintf("Resolve_TestCase%d", i), func(t *testing.T) {
@@ -44,3 +73,29 @@ func TestConfig_ResolveAliases(t *testing.T) {
 		})
 	}
 }
+
+func TestAlias_Placeholders(t *testing.T) {
+	a := &alias.Alias{
+		Name:    "ssh-prod",
+		Command: []string{"instance", "server", "ssh", "$2", "username=${user:-admin}", "zone=${zone}", "$1", "$@", "name=$1"},
+	}
+	assert.True(t, a.HasPlaceholders())
+	assert.Equal(t, "<$1> <$2> [$@...] [user=admin] zone=<value>", a.Usage())
+	assert.NoError(t, a.Validate())
+
+	assert.False(t, (&alias.Alias{Name: "isl", Command: []string{"instance", "server", "list"}}).HasPlaceholders())
+	assert.Error(t, (&alias.Alias{Name: "invalid", Command: []string{"instance", "server", "get", "${zone"}}).Validate())
+	assert.Error(t, (&alias.Alias{Name: "invalid", Command: []string{"instance", "server", "get", "$0"}}).Validate())
+}
+
+func TestConfig_CompletePlaceholders(t *testing.T) {
+	config := &alias.Config{Aliases: map[string][]string{
+		"ssh-prod": {"instance", "server", "ssh", "$1", "username=${user:-admin}", "zone=${zone:-nl-ams-1}"},
+	}}
+
+	assert.Equal(t, []string{"user=", "zone="}, config.CompletePlaceholders([]string{"scw", "ssh-prod"}, ""))
+	assert.Equal(t, []string{"zone="}, config.CompletePlaceholders([]string{"scw", "ssh-prod", "user=root"}, ""))
+	assert.Equal(t, []string{"user="}, config.CompletePlaceholders([]string{"scw", "ssh-prod"}, "u"))
+	assert.Nil(t, config.CompletePlaceholders([]string{"scw", "ssh-prod", "-o", "json"}, ""))
+	assert.Nil(t, config.CompletePlaceholders([]string{"scw", "instance"}, ""))
+}
@@ -1,38 +1,185 @@
 package cache
 
-import "strings"
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
 
+const (
+	// DefaultTTL is the time an entry is kept when no TTL is given.
+	DefaultTTL = 5 * time.Minute
+
+	// MaxEntriesPerNamespace is the number of entries kept for a namespace, entries expiring first are evicted.
+	MaxEntriesPerNamespace = 50
+
+	// MaxEntrySize is the size of the largest value that can be stored, larger values are not cached.
+	MaxEntrySize = 1 << 20
+
+	filePermission = 0o600
+)
+
+// Cache stores command responses grouped by namespace (e.g. instance).
+// When a directory is given, each namespace is persisted in its own file so that entries
+// can be reused by the next commands until they expire.
+// The cache is best effort: values that cannot be read or written are ignored.
 type Cache struct {
-	m map[string]interface{}
+	dir string
+
+	mu         sync.Mutex
+	namespaces map[string]map[string]*entry
+	now        func() time.Time
+}
+
+type entry struct {
+	Value     json.RawMessage `json:"value"`
+	ExpiresAt time.Time       `json:"expires_at"`
 }
 
-func New() *Cache {
+// New returns a cache persisted in dir, or kept in memory if dir is empty.
+func New(dir string) *Cache {
 	return &Cache{
-		m: map[string]interface{}{},
+		dir:        dir,
+		namespaces: map[string]map[string]*entry{},
+		now:        time.Now,
 	}
 }
 
-func (c *Cache) Set(cmd string, resp interface{}) {
+// Set stores value for key in namespace, the value is encoded in JSON.
+// A ttl of 0 uses DefaultTTL.
+func (c *Cache) Set(namespace string, key string, value interface{}, ttl time.Duration) {
 	if c == nil {
 		return
 	}
-	c.m[cmd] = resp
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil || len(raw) > MaxEntrySize {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := c.load(namespace)
+	entries[key] = &entry{
+		Value:     raw,
+		ExpiresAt: c.now().Add(ttl),
+	}
+	evict(entries)
+	c.save(namespace, entries)
 }
 
-func (c *Cache) Get(cmd string) interface{} {
+// Get decodes the value stored for key in namespace into value.
+// It returns false if the entry does not exist or has expired.
+func (c *Cache) Get(namespace string, key string, value interface{}) bool {
 	if c == nil {
-		return nil
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, exists := c.load(namespace)[key]
+	if !exists || !c.now().Before(e.ExpiresAt) {
+		return false
 	}
-	return c.m[cmd]
+	return json.Unmarshal(e.Value, value) == nil
 }
 
+// Update invalidates all entries of a namespace, it must be called when resources of the namespace are modified.
 func (c *Cache) Update(namespace string) {
 	if c == nil {
 		return
 	}
-	for k := range c.m {
-		if strings.HasPrefix(k, namespace) {
-			delete(c.m, k)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.namespaces, namespace)
+	if c.dir != "" {
+		_ = os.Remove(c.namespacePath(namespace))
+	}
+}
+
+// load returns the entries of a namespace that have not expired, reading them from disk the first time.
+func (c *Cache) load(namespace string) map[string]*entry {
+	entries, loaded := c.namespaces[namespace]
+	if !loaded {
+		entries = map[string]*entry{}
+		if c.dir != "" {
+			content, err := os.ReadFile(c.namespacePath(namespace))
+			if err == nil {
+				_ = json.Unmarshal(content, &entries)
+			}
+		}
+		c.namespaces[namespace] = entries
+	}
+
+	now := c.now()
+	for key, e := range entries {
+		if e == nil || !now.Before(e.ExpiresAt) {
+			delete(entries, key)
 		}
 	}
+	return entries
+}
+
+// save writes the entries of a namespace to a temporary file renamed afterward
+// so that concurrent commands never read a partial file.
+func (c *Cache) save(namespace string, entries map[string]*entry) {
+	if c.dir == "" {
+		return
+	}
+
+	content, err := json.Marshal(entries)
+	if err != nil {
+		return
+	}
+
+	err = os.MkdirAll(c.dir, 0o700)
+	if err != nil {
+		return
+	}
+
+	tmpFile, err := os.CreateTemp(c.dir, namespace+".*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(content)
+	closeErr := tmpFile.Close()
+	if err != nil || closeErr != nil {
+		return
+	}
+	_ = os.Chmod(tmpFile.Name(), filePermission)
+	_ = os.Rename(tmpFile.Name(), c.namespacePath(namespace))
+}
+
+func (c *Cache) namespacePath(namespace string) string {
+	return filepath.Join(c.dir, filepath.Base(namespace)+".json")
+}
+
+// evict removes the entries expiring first until the namespace fits in MaxEntriesPerNamespace.
+func evict(entries map[string]*entry) {
+	if len(entries) <= MaxEntriesPerNamespace {
+		return
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return entries[keys[i]].ExpiresAt.Before(entries[keys[j]].ExpiresAt)
+	})
+	for _, key := range keys[:len(keys)-MaxEntriesPerNamespace] {
+		delete(entries, key)
+	}
 }
@@ -0,0 +1,83 @@
+package cache_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+	"github.com/scaleway/scaleway-cli/v2/internal/cache"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("persisted", func(t *testing.T) {
+		dir := t.TempDir()
+		cache.New(dir).Set("instance", "server list", []string{"web", "db"}, time.Minute)
+
+		values := []string(nil)
+		assert.True(t, cache.New(dir).Get("instance", "server list", &values))
+		assert.Equal(t, []string{"web", "db"}, values)
+		assert.False(t, cache.New(dir).Get("rdb", "server list", &values))
+	})
+
+	t.Run("in memory", func(t *testing.T) {
+		c := cache.New("")
+		c.Set("instance", "server list", []string{"web"}, time.Minute)
+
+		values := []string(nil)
+		assert.True(t, c.Get("instance", "server list", &values))
+		assert.Equal(t, []string{"web"}, values)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		dir := t.TempDir()
+		c := cache.New(dir)
+		c.Set("instance", "server list", []string{"web"}, time.Nanosecond)
+		time.Sleep(time.Millisecond)
+
+		values := []string(nil)
+		assert.False(t, c.Get("instance", "server list", &values))
+		assert.False(t, cache.New(dir).Get("instance", "server list", &values))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		dir := t.TempDir()
+		c := cache.New(dir)
+		c.Set("instance", "server list", []string{"web"}, time.Minute)
+		c.Set("rdb", "instance list", []string{"db"}, time.Minute)
+		c.Update("instance")
+
+		values := []string(nil)
+		assert.False(t, c.Get("instance", "server list", &values))
+		assert.False(t, cache.New(dir).Get("instance", "server list", &values))
+		assert.True(t, cache.New(dir).Get("rdb", "instance list", &values))
+		_, err := os.Stat(filepath.Join(dir, "instance.json"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("size limits", func(t *testing.T) {
+		c := cache.New(t.TempDir())
+		for i := range cache.MaxEntriesPerNamespace + 1 {
+			c.Set("instance", fmt.Sprintf("key-%d", i), i, time.Duration(i+1)*time.Minute)
+		}
+		c.Set("instance", "large", make([]byte, cache.MaxEntrySize), time.Minute)
+
+		value := 0
+		assert.False(t, c.Get("instance", "key-0", &value))
+		assert.True(t, c.Get("instance", "key-1", &value))
+		assert.Equal(t, 1, value)
+		large := []byte(nil)
+		assert.False(t, c.Get("instance", "large", &large))
+	})
+
+	t.Run("nil cache", func(t *testing.T) {
+		var c *cache.Cache
+		c.Set("instance", "server list", []string{"web"}, time.Minute)
+		c.Update("instance")
+
+		values := []string(nil)
+		assert.False(t, c.Get("instance", "server list", &values))
+	})
+}
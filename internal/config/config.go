@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"text/template"
+	"time"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/alias"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -25,6 +26,9 @@ const (
 # Output sets the output format for all commands you run
 {{ if .Output }}output: {{ .Output }}{{ else }}# output: human{{ end }}
 
+# SkipConfirmation runs destructive commands, e.g. deletions, without asking for a confirmation like --yes
+{{ if .SkipConfirmation }}skip_confirmation: true{{ else }}# skip_confirmation: true{{ end }}
+
 # Alias creates custom aliases for your Scaleway CLI commands
 {{- if .Alias }}
 alias:
@@ -43,16 +47,204 @@ alias:
 #             - server
 #             - list
 {{- end }}
+
+# Human customizes the human output
+{{- if .Human }}
+human:
+    {{- if .Human.Theme }}
+    theme: {{ .Human.Theme }}
+    {{- end }}
+    {{- if .Human.Timestamps }}
+    timestamps: {{ .Human.Timestamps }}
+    {{- end }}
+    {{- if .Human.Timezone }}
+    timezone: {{ .Human.Timezone }}
+    {{- end }}
+    {{- if .Human.SizeUnits }}
+    size_units: {{ .Human.SizeUnits }}
+    {{- end }}
+{{- else }}
+# human:
+#     theme: default
+#     timestamps: relative
+#     timezone: Local
+#     size_units: auto
+{{- end }}
+
+# Retry sets how failed API requests are retried
+{{- if .Retry }}
+retry:
+    {{- if .Retry.MaxAttempts }}
+    max_attempts: {{ .Retry.MaxAttempts }}
+    {{- end }}
+    {{- if .Retry.MaxDuration }}
+    max_duration: {{ .Retry.MaxDuration }}
+    {{- end }}
+    {{- if .Retry.BaseDelay }}
+    base_delay: {{ .Retry.BaseDelay }}
+    {{- end }}
+    {{- if .Retry.MaxDelay }}
+    max_delay: {{ .Retry.MaxDelay }}
+    {{- end }}
+{{- else }}
+# retry:
+#     max_attempts: 5
+#     max_duration: 2m
+#     base_delay: 500ms
+#     max_delay: 30s
+{{- end }}
+
+# Hooks run commands before and after the CLI commands matching a glob, e.g. instance.server.delete or *.*.create
+{{- if .Hooks }}
+hooks:
+    {{- range $glob, $hook := .Hooks }}
+    {{ printf "%q" $glob }}:
+        {{- if $hook.Pre }}
+        pre:
+        {{- range $hook.Pre }}
+            - {{ printf "%q" . }}
+        {{- end }}
+        {{- end }}
+        {{- if $hook.Post }}
+        post:
+        {{- range $hook.Post }}
+            - {{ printf "%q" . }}
+        {{- end }}
+        {{- end }}
+    {{- end }}
+{{- else }}
+# hooks:
+#     "*.*.delete":
+#         pre:
+#             - ./check-delete.sh
+#         post:
+#             - ./changelog.sh
+{{- end }}
+
+# Defaults are the values of the arguments of a command used when they are not given, keyed by command path
+{{- if .Defaults }}
+defaults:
+    {{- range $path, $args := .Defaults }}
+    {{ $path }}:
+        {{- range $name, $value := $args }}
+        {{ $name }}: {{ printf "%q" $value }}
+        {{- end }}
+    {{- end }}
+{{- else }}
+# defaults:
+#     instance.server.create:
+#         type: PRO2-S
+#         tags.0: team-x
+{{- end }}
+
+# TagPolicies are the key=value tags that create commands must set on resources, missing ones can be filled from a template
+{{- if .TagPolicies }}
+tag_policies:
+    {{- range $policy := .TagPolicies }}
+    -
+        {{- if $policy.Namespaces }}
+        namespaces:
+        {{- range $policy.Namespaces }}
+            - {{ . }}
+        {{- end }}
+        {{- end }}
+        {{- if $policy.Required }}
+        required:
+        {{- range $key, $regexp := $policy.Required }}
+            {{ $key }}: {{ printf "%q" $regexp }}
+        {{- end }}
+        {{- end }}
+        {{- if $policy.Template }}
+        template:
+        {{- range $key, $value := $policy.Template }}
+            {{ $key }}: {{ printf "%q" $value }}
+        {{- end }}
+        {{- end }}
+    {{- end }}
+{{- else }}
+# tag_policies:
+#     - namespaces:
+#           - instance
+#       required:
+#           cost-center: "^[0-9]{4}$"
+#           owner: ""
+#       template:
+#           owner: "{{ "{{" }} env \"USER\" {{ "}}" }}"
+{{- end }}
 `
 )
 
 type Config struct {
-	Alias  *alias.Config `json:"alias"  yaml:"alias"`
-	Output string        `json:"output" yaml:"output"`
+	Alias  *alias.Config          `json:"alias"  yaml:"alias"`
+	Output string                 `json:"output" yaml:"output"`
+	Human  *HumanConfig           `json:"human"  yaml:"human"`
+	Retry  *RetryConfig           `json:"retry"  yaml:"retry"`
+	Hooks  map[string]*HookConfig `json:"hooks" yaml:"hooks"`
+
+	// Defaults are the values of args used when they are not given, keyed by command path then arg name
+	// e.g. instance.server.create: {type: PRO2-S, tags.0: team-x}
+	Defaults map[string]map[string]string `json:"defaults" yaml:"defaults"`
+
+	// TagPolicies are the tags that create commands must set on resources
+	TagPolicies []*TagPolicyConfig `json:"tag_policies" yaml:"tag_policies"`
+
+	// SkipConfirmation runs destructive commands without confirmation, like the --yes flag
+	SkipConfirmation bool `json:"skip_confirmation" yaml:"skip_confirmation"`
 
 	path string
 }
 
+// HumanConfig customizes the human output
+type HumanConfig struct {
+	// Theme is the color theme: default, bright, colorblind or none to disable colors
+	Theme string `json:"theme,omitempty" yaml:"theme,omitempty"`
+
+	// Timestamps prints dates as relative (e.g. 3 days ago) or absolute
+	Timestamps string `json:"timestamps,omitempty" yaml:"timestamps,omitempty"`
+
+	// Timezone is used to print absolute dates, e.g. Europe/Paris, UTC or Local
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+
+	// SizeUnits prints sizes with SI (e.g. GB) or IEC (e.g. GiB) units, auto picks one depending on the size
+	SizeUnits string `json:"size_units,omitempty" yaml:"size_units,omitempty"`
+}
+
+// RetryConfig sets how failed API requests are retried
+type RetryConfig struct {
+	// MaxAttempts is the number of times a request is sent, including the first one
+	MaxAttempts *int `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty"`
+
+	// MaxDuration is the total time after which a request is no longer retried
+	MaxDuration *time.Duration `json:"max_duration,omitempty" yaml:"max_duration,omitempty"`
+
+	// BaseDelay is the delay before the first retry, it doubles for each following retry
+	BaseDelay *time.Duration `json:"base_delay,omitempty" yaml:"base_delay,omitempty"`
+
+	// MaxDelay caps the delay between two retries
+	MaxDelay *time.Duration `json:"max_delay,omitempty" yaml:"max_delay,omitempty"`
+}
+
+// HookConfig lists the commands run before and after the CLI commands matching a glob
+type HookConfig struct {
+	// Pre commands run before the CLI command, the CLI command is aborted if one of them fails
+	Pre []string `json:"pre,omitempty" yaml:"pre,omitempty"`
+
+	// Post commands run after the CLI command
+	Post []string `json:"post,omitempty" yaml:"post,omitempty"`
+}
+
+// TagPolicyConfig lists the tags that the resources created with the CLI must have, as key=value tags
+type TagPolicyConfig struct {
+	// Namespaces are the namespaces of the create commands the policy applies to, e.g. instance, all namespaces when empty
+	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+
+	// Required maps the keys of required tags to a regular expression their value must match, any value matches an empty one
+	Required map[string]string `json:"required,omitempty" yaml:"required,omitempty"`
+
+	// Template fills missing required tags, its values are Go templates, e.g. {{ env "USER" }}
+	Template map[string]string `json:"template,omitempty" yaml:"template,omitempty"`
+}
+
 // LoadConfig tries to load config file
 // returns a new empty config if file doesn't exist
 // return error if fail to load config file
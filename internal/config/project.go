@@ -0,0 +1,151 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/alias"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/scaleway-sdk-go/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigFileNames are the names of the project config file, looked for in this order in each directory.
+var ProjectConfigFileNames = []string{".scw.yaml", filepath.Join(".scw", "config.yaml")}
+
+// ProjectConfig is a config file of a directory tree, e.g. a repository, found from the working directory.
+// Its values have priority over the config files in the home directory but not over environment variables and flags.
+type ProjectConfig struct {
+	// Profile selects the profile of the config file
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+
+	DefaultProjectID string `json:"default_project_id,omitempty" yaml:"default_project_id,omitempty"`
+	DefaultRegion    string `json:"default_region,omitempty"     yaml:"default_region,omitempty"`
+	DefaultZone      string `json:"default_zone,omitempty"       yaml:"default_zone,omitempty"`
+
+	// Output sets the output format like the output of the CLI config
+	Output string `json:"output,omitempty" yaml:"output,omitempty"`
+
+	// Aliases are added to the aliases of the CLI config, they cannot replace a command word or an alias of the CLI config
+	Aliases map[string][]string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+
+	// Path is the path of the file
+	Path string `json:"-" yaml:"-"`
+}
+
+// FindProjectConfig looks for a project config file in a directory and its parents.
+// It returns nil if there is none.
+func FindProjectConfig(dir string) (*ProjectConfig, error) {
+	if runtime.GOARCH == "wasm" {
+		return nil, nil
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		for _, name := range ProjectConfigFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return LoadProjectConfig(path)
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// LoadProjectConfig loads and validates a project config file.
+func LoadProjectConfig(path string) (*ProjectConfig, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project config file: %w", err)
+	}
+
+	config := &ProjectConfig{}
+	decoder := yaml.NewDecoder(bytes.NewReader(file))
+	decoder.KnownFields(true)
+	err = decoder.Decode(config)
+	// An empty file is a valid project config file.
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to unmarshal project config file %s: %w", path, err)
+	}
+	config.Path = path
+
+	switch {
+	case config.DefaultProjectID != "" && !validation.IsProjectID(config.DefaultProjectID):
+		return nil, fmt.Errorf("invalid default_project_id '%s' in project config file %s", config.DefaultProjectID, path)
+	case config.DefaultRegion != "" && !validation.IsRegion(config.DefaultRegion):
+		return nil, fmt.Errorf("invalid default_region '%s' in project config file %s", config.DefaultRegion, path)
+	case config.DefaultZone != "" && !validation.IsZone(config.DefaultZone):
+		return nil, fmt.Errorf("invalid default_zone '%s' in project config file %s", config.DefaultZone, path)
+	}
+
+	return config, nil
+}
+
+// ScwProfile returns the values of the project config file overriding the ones of the profile.
+// The region of the zone is used when only the zone is set, to keep them consistent.
+func (c *ProjectConfig) ScwProfile() *scw.Profile {
+	profile := &scw.Profile{}
+	if c == nil {
+		return profile
+	}
+	if c.DefaultProjectID != "" {
+		profile.DefaultProjectID = scw.StringPtr(c.DefaultProjectID)
+	}
+	if c.DefaultRegion != "" {
+		profile.DefaultRegion = scw.StringPtr(c.DefaultRegion)
+	}
+	if c.DefaultZone != "" {
+		profile.DefaultZone = scw.StringPtr(c.DefaultZone)
+		if zone, err := scw.ParseZone(c.DefaultZone); err == nil && c.DefaultRegion == "" {
+			if region, err := zone.Region(); err == nil {
+				profile.DefaultRegion = scw.StringPtr(region.String())
+			}
+		}
+	}
+	return profile
+}
+
+// MergeAliases returns the aliases of the CLI config with the ones of the project config file.
+// The aliases of the CLI config are not modified as they are saved by alias commands.
+// Aliases of the project config file cannot be named like a command word or an alias of the CLI config: a file found
+// in a repository could otherwise change what a command does, e.g. list: [delete].
+func (c *ProjectConfig) MergeAliases(aliases *alias.Config, commandWords map[string]bool) (*alias.Config, error) {
+	if c == nil || len(c.Aliases) == 0 {
+		return aliases, nil
+	}
+	merged := alias.EmptyConfig()
+	for name, command := range aliases.Aliases {
+		merged.Aliases[name] = command
+	}
+
+	names := make([]string, 0, len(c.Aliases))
+	for name := range c.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if commandWords[name] {
+			return nil, fmt.Errorf("alias '%s' of project config file %s is a command word", name, c.Path)
+		}
+		if _, exists := aliases.Aliases[name]; exists {
+			return nil, fmt.Errorf("alias '%s' of project config file %s is already an alias of the CLI config", name, c.Path)
+		}
+		merged.Aliases[name] = c.Aliases[name]
+	}
+	return merged, nil
+}
@@ -0,0 +1,40 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	workDir := filepath.Join(root, "repo", "src", "app")
+	require.NoError(t, os.MkdirAll(workDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "repo", ".scw.yaml"), []byte("default_zone: nl-ams-2\n"), 0o600))
+
+	projectConfig, err := config.FindProjectConfig(workDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "repo", ".scw.yaml"), projectConfig.Path)
+	assert.Equal(t, "nl-ams-2", *projectConfig.ScwProfile().DefaultZone)
+	assert.Equal(t, "nl-ams", *projectConfig.ScwProfile().DefaultRegion)
+
+	// The closest file is used.
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "repo", "src", ".scw"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "repo", "src", ".scw", "config.yaml"), []byte("profile: dev\n"), 0o600))
+	projectConfig, err = config.FindProjectConfig(workDir)
+	require.NoError(t, err)
+	assert.Equal(t, "dev", projectConfig.Profile)
+	assert.Nil(t, projectConfig.ScwProfile().DefaultZone)
+
+	projectConfig, err = config.FindProjectConfig(root)
+	require.NoError(t, err)
+	assert.Nil(t, projectConfig)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "repo", "src", ".scw", "config.yaml"), []byte("profiles: dev\n"), 0o600))
+	_, err = config.FindProjectConfig(workDir)
+	assert.ErrorContains(t, err, "field profiles not found")
+}
@@ -169,3 +169,89 @@ func IsNil(value reflect.Value) bool {
 func isFieldPublic(field reflect.StructField) bool {
 	return field.Name[0] >= 'A' && field.Name[0] <= 'Z'
 }
+
+// ResolvePath converts a path using JSON or snake_case field names into a path that can be used with GetType or GetValue.
+// E.g t = User, path = "friends.0.name" will return "Friends.0.Name"
+// Each struct field is matched on its Go name, its JSON name or its name without case and underscores.
+func ResolvePath(t reflect.Type, path string) (string, error) {
+	resolved, err := resolvePath(t, []string{"$"}, strings.Split(path, "."))
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(resolved, "."), nil
+}
+
+func resolvePath(t reflect.Type, parents []string, path []string) ([]string, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	if t.Kind() == reflect.Ptr {
+		return resolvePath(t.Elem(), parents, path)
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		_, err := strconv.Atoi(path[0])
+		if err != nil {
+			return nil, fmt.Errorf("trying to access array %s but %s is not a numerical index", strings.Join(parents, "."), path[0])
+		}
+		resolved, err := resolvePath(t.Elem(), append(parents, path[0]), path[1:])
+		if err != nil {
+			return nil, err
+		}
+		return append([]string{path[0]}, resolved...), nil
+	case reflect.Map:
+		resolved, err := resolvePath(t.Elem(), append(parents, path[0]), path[1:])
+		if err != nil {
+			return nil, err
+		}
+		return append([]string{path[0]}, resolved...), nil
+	case reflect.Struct:
+		field, exist := findField(t, path[0])
+		if !exist {
+			return nil, fmt.Errorf("field %s does not exist in %s", path[0], strings.Join(parents, "."))
+		}
+		resolved, err := resolvePath(field.Type, append(parents, field.Name), path[1:])
+		if err != nil {
+			return nil, err
+		}
+		return append([]string{field.Name}, resolved...), nil
+	default:
+		return nil, fmt.Errorf("cannot get %s in field %s", strings.Join(path, "."), strings.Join(parents, "."))
+	}
+}
+
+// findField looks for a public field, including the ones of embedded structs, matching the given name.
+func findField(t reflect.Type, name string) (reflect.StructField, bool) {
+	if field, exist := t.FieldByName(name); exist && isFieldPublic(field) {
+		return field, true
+	}
+
+	fields := reflect.VisibleFields(t)
+	for _, field := range fields {
+		if isFieldPublic(field) && !field.Anonymous && jsonFieldName(field) == name {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if isFieldPublic(field) && !field.Anonymous && normalizeFieldName(field.Name) == normalizeFieldName(name) {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// jsonFieldName returns the name of a field in its JSON representation.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
@@ -223,6 +223,72 @@ func TestGetType(t *testing.T) {
 	}))
 }
 
+func TestResolvePath(t *testing.T) {
+	type PublicIP struct {
+		Address string `json:"address"`
+	}
+
+	type Server struct {
+		Name         string    `json:"name"`
+		PublicIP     *PublicIP `json:"public_ip"`
+		CreationDate string    `json:"created_at"`
+	}
+
+	type TestCase struct {
+		Data     reflect.Type
+		Path     string
+		Expected string
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			t.Helper()
+			actual, err := gofields.ResolvePath(tc.Data, tc.Path)
+			if err != nil {
+				assert.Equal(t, tc.Expected, err.Error())
+			} else {
+				assert.Equal(t, tc.Expected, actual)
+			}
+		}
+	}
+
+	t.Run("GoName", run(&TestCase{
+		Data:     reflect.TypeOf(&User{}),
+		Path:     "Address.Zip",
+		Expected: "Address.Zip",
+	}))
+	t.Run("SnakeCase", run(&TestCase{
+		Data:     reflect.TypeOf(&User{}),
+		Path:     "friends.0.name",
+		Expected: "Friends.0.Name",
+	}))
+	t.Run("Map", run(&TestCase{
+		Data:     reflect.TypeOf(&User{}),
+		Path:     "pets.rex.species",
+		Expected: "Pets.rex.Species",
+	}))
+	t.Run("JSONName", run(&TestCase{
+		Data:     reflect.TypeOf(&Server{}),
+		Path:     "created_at",
+		Expected: "CreationDate",
+	}))
+	t.Run("Nested", run(&TestCase{
+		Data:     reflect.TypeOf([]*Server{}),
+		Path:     "0.public_ip.address",
+		Expected: "0.PublicIP.Address",
+	}))
+	t.Run("UnknownField", run(&TestCase{
+		Data:     reflect.TypeOf(&Server{}),
+		Path:     "public_ip.unknown",
+		Expected: "field unknown does not exist in $.PublicIP",
+	}))
+	t.Run("PrivateField", run(&TestCase{
+		Data:     reflect.TypeOf(&User{}),
+		Path:     "address.digicode",
+		Expected: "field digicode does not exist in $.Address",
+	}))
+}
+
 func TestListFields(t *testing.T) {
 	type TestCase struct {
 		Data     reflect.Type
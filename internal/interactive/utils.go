@@ -3,6 +3,7 @@
 package interactive
 
 import (
+	"context"
 	"errors"
 	"io"
 	"os"
@@ -41,6 +42,16 @@ func isInteractive() bool {
 		isatty.IsCygwinTerminal(os.Stdin.Fd()) && isatty.IsCygwinTerminal(os.Stderr.Fd()) // windows cygwin terminal
 }
 
+// CanPrompt returns true if the user can answer prompts: the CLI runs in a terminal and stdin reads from it.
+// Prompts mocked in tests can always be answered.
+func CanPrompt(ctx context.Context, stdin io.Reader) bool {
+	if hasMockedResponse(ctx) {
+		return true
+	}
+	f, isFile := stdin.(*os.File)
+	return IsInteractive && isFile && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
 func ValidateOrganizationID() ValidateFunc {
 	return func(s string) error {
 		if !validation.IsOrganizationID(s) {
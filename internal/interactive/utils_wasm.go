@@ -3,6 +3,7 @@
 package interactive
 
 import (
+	"context"
 	"io"
 )
 
@@ -21,3 +22,8 @@ var (
 func SetOutputWriter(w io.Writer) {
 	outputWriter = w
 }
+
+// CanPrompt returns true if the user can answer prompts, prompts are disabled for this build.
+func CanPrompt(_ context.Context, _ io.Reader) bool {
+	return false
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/alias"
 	"github.com/scaleway/scaleway-cli/v2/internal/pkg/shlex"
 )
 
@@ -37,6 +38,15 @@ aliases in your commands are evaluated and you get completion
   with: isl = instance server list
     "scw isl <TAB>" will complete as "scw instance server list <TAB>"
     "scw <TAB>" will complete "isl"
+
+Aliases can use placeholders, replaced by the words following the alias until the first flag:
+  - $1, $2... are replaced by the first, second... word
+  - $@ is replaced by the words not used by $1, $2...
+  - ${name} and ${name:-default} are replaced by the value of a name=value word, or by the default value
+  with: ssh-prod = instance server ssh $1 username=${user:-admin} zone=nl-ams-1
+    "scw ssh-prod 11111111-1111-1111-1111-111111111111 user=root" will run
+    "scw instance server ssh 11111111-1111-1111-1111-111111111111 username=root zone=nl-ams-1"
+The words that are not used by placeholders are added after the command.
 `,
 		Examples: []*core.Example{
 			{
@@ -47,6 +57,10 @@ aliases in your commands are evaluated and you get completion
 				Short: "Create an alias for a verb",
 				Raw:   `scw alias create c command=create`,
 			},
+			{
+				Short: "Create an alias with a server ID and an optional user as placeholders",
+				Raw:   `scw alias create ssh-prod command='instance server ssh $1 username=${user:-admin} zone=nl-ams-1'`,
+			},
 		},
 		Namespace: "alias",
 	}
@@ -59,10 +73,11 @@ type CreateRequest struct {
 
 func aliasCreateCommand() *core.Command {
 	return &core.Command{
-		Short:     "Create a new alias for a command",
-		Namespace: "alias",
-		Resource:  "create",
-		Long:      `This command help you create aliases and save it to your config`,
+		Short:        "Create a new alias for a command",
+		Namespace:    "alias",
+		Resource:     "create",
+		LocalEffects: true,
+		Long:         `This command help you create aliases and save it to your config`,
 		Examples: []*core.Example{
 			{
 				Short: "Create a custom alias 'isl' for 'instance server list'",
@@ -72,6 +87,10 @@ func aliasCreateCommand() *core.Command {
 				Short: "Add an alias to a verb",
 				Raw:   `scw alias create c command=create`,
 			},
+			{
+				Short: "Create an alias listing the servers with a tag given as first word",
+				Raw:   `scw alias create isl-tag command='instance server list tags.0=$1'`,
+			},
 		},
 		AllowAnonymousClient: true,
 		ArgSpecs: core.ArgSpecs{
@@ -84,7 +103,7 @@ func aliasCreateCommand() *core.Command {
 			{
 				Name:       "command",
 				OneOfGroup: "command",
-				Short:      "Command to create an alias for",
+				Short:      "Command to create an alias for, it can use the placeholders $1, $2..., $@, ${name} and ${name:-default}",
 			},
 		},
 		ArgsType: reflect.TypeOf(CreateRequest{}),
@@ -99,6 +118,13 @@ func aliasCreateCommand() *core.Command {
 			if err != nil {
 				return nil, fmt.Errorf("failed to parse command: %w", err)
 			}
+			err = (&alias.Alias{Name: args.Alias, Command: command}).Validate()
+			if err != nil {
+				return nil, &core.CliError{
+					Err:  err,
+					Hint: "Quote the command with single quotes so that your shell does not replace the placeholders, e.g. command='instance server ssh $1'",
+				}
+			}
 			replaced := cfg.Alias.AddAlias(args.Alias, command)
 			if replaced {
 				response.Alias = "replaced"
@@ -125,6 +151,8 @@ type ListRequest struct {
 type aliasListItem struct {
 	Alias   string
 	Command string
+	// Arguments are the placeholders of the alias, e.g. <$1> [user=admin]
+	Arguments string
 }
 
 type aliasListResponse []aliasListItem
@@ -188,8 +216,9 @@ func aliasListCommand() *core.Command {
 
 			for key, value := range aliasCfg.Aliases {
 				aliases = append(aliases, aliasListItem{
-					Alias:   key,
-					Command: strings.Join(value, " "),
+					Alias:     key,
+					Command:   strings.Join(value, " "),
+					Arguments: (&alias.Alias{Name: key, Command: value}).Usage(),
 				})
 			}
 
@@ -224,6 +253,7 @@ func aliasDeleteCommand() *core.Command {
 		Short:                "Delete an alias",
 		Namespace:            "alias",
 		Resource:             "delete",
+		LocalEffects:         true,
 		AllowAnonymousClient: true,
 		ArgSpecs: core.ArgSpecs{
 			{
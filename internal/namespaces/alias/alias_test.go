@@ -67,6 +67,34 @@ func Test_Alias(t *testing.T) {
 		TmpHomeDir: true,
 	}))
 
+	t.Run("list placeholders", core.Test(&core.TestConfig{
+		BeforeFunc: core.BeforeFuncCombine(
+			core.ExecBeforeCmdArgs([]string{"scw", "alias", "create", "ssh-prod", "command=instance server ssh $1 username=${user:-admin} zone=nl-ams-1"}),
+		),
+		Commands:      commands.GetCommands(),
+		Cmd:           "scw alias list",
+		EnableAliases: true,
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			func(t *testing.T, ctx *core.CheckFuncCtx) {
+				t.Helper()
+				assert.Contains(t, string(ctx.Stdout), "<$1> [user=admin]")
+			},
+		),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("invalid placeholder", core.Test(&core.TestConfig{
+		Commands:      commands.GetCommands(),
+		Args:          []string{"scw", "alias", "create", "srv", "command=instance server get ${id"},
+		EnableAliases: true,
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+		TmpHomeDir: true,
+	}))
+
 	t.Run("delete alias", core.Test(&core.TestConfig{
 		BeforeFunc: core.BeforeFuncCombine(
 			core.ExecBeforeCmd("scw alias create i command=instance"),
@@ -55,8 +55,8 @@ func waitForServerFunc(action int) core.WaitFunc {
 		server, err := applesilicon.NewAPI(core.ExtractClient(ctx)).WaitForServer(&applesilicon.WaitForServerRequest{
 			Zone:          respI.(*applesilicon.Server).Zone,
 			ServerID:      respI.(*applesilicon.Server).ID,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(serverActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 
 		switch action {
@@ -98,8 +98,8 @@ func serverWaitCommand() *core.Command {
 			cluster, err := api.WaitForServer(&applesilicon.WaitForServerRequest{
 				Zone:          args.Zone,
 				ServerID:      args.ServerID,
-				Timeout:       args.Timeout,
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, args.Timeout),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 			if err != nil {
 				return nil, err
@@ -23,13 +23,14 @@ type serverSSHConnectRequest struct {
 
 func serverSSHCommand() *core.Command {
 	return &core.Command{
-		Short:     `SSH into a server`,
-		Long:      `Connect to distant server via the SSH protocol.`,
-		Namespace: "apple-silicon",
-		Verb:      "ssh",
-		Resource:  "server",
-		Groups:    []string{"utility"},
-		ArgsType:  reflect.TypeOf(serverSSHConnectRequest{}),
+		Short:        `SSH into a server`,
+		Long:         `Connect to distant server via the SSH protocol.`,
+		Namespace:    "apple-silicon",
+		Verb:         "ssh",
+		LocalEffects: true,
+		Resource:     "server",
+		Groups:       []string{"utility"},
+		ArgsType:     reflect.TypeOf(serverSSHConnectRequest{}),
 		ArgSpecs: core.ArgSpecs{
 			{
 				Name:       "server-id",
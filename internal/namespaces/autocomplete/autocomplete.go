@@ -31,6 +31,7 @@ func GetCommands() *core.Commands {
 
 	for _, cmd := range cmds.GetAll() {
 		cmd.DisableAfterChecks = true
+		cmd.DisableHistory = true
 	}
 
 	return cmds
@@ -145,6 +146,7 @@ func autocompleteInstallCommand() *core.Command {
 		Long:                 `Install autocomplete script for a given shell and OS.`,
 		Namespace:            "autocomplete",
 		Resource:             "install",
+		LocalEffects:         true,
 		AllowAnonymousClient: true,
 		ArgSpecs: core.ArgSpecs{
 			{
@@ -291,6 +293,7 @@ func autocompleteCompleteBashCommand() *core.Command {
 			// If the wordToComplete is an argument label (cf. `arg=`), remove
 			// this prefix for all suggestions.
 			res := core.AutoComplete(ctx, leftWords, wordToComplete, rightWords)
+			res.Suggestions = append(res.Suggestions, aliases.CompletePlaceholders(words[:wordIndex], wordToComplete)...)
 			if strings.Contains(wordToComplete, "=") {
 				prefix := strings.SplitAfterN(wordToComplete, "=", 2)[0]
 				for k, p := range res.Suggestions {
@@ -332,6 +335,7 @@ func autocompleteCompleteFishCommand() *core.Command {
 			rightWords := []string(nil)
 
 			res := core.AutoComplete(ctx, leftWords, wordToComplete, rightWords)
+			res.Suggestions = append(res.Suggestions, aliases.CompletePlaceholders(rawArgs[3:], wordToComplete)...)
 
 			// TODO: decide if we want to add descriptions
 			// see https://stackoverflow.com/a/20879411
@@ -383,6 +387,7 @@ func autocompleteCompleteZshCommand() *core.Command {
 			rightWords := aliases.ResolveAliases(words[wordIndex+1:])
 
 			res := core.AutoComplete(ctx, leftWords, wordToComplete, rightWords)
+			res.Suggestions = append(res.Suggestions, aliases.CompletePlaceholders(words[:wordIndex], wordToComplete)...)
 			return strings.Join(res.Suggestions, " "), nil
 		},
 	}
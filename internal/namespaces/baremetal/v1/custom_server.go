@@ -48,8 +48,8 @@ func serverWaitCommand() *core.Command {
 			server, err := api.WaitForServer(&baremetal.WaitForServerRequest{
 				ServerID:      args.ServerID,
 				Zone:          args.Zone,
-				Timeout:       scw.TimeDurationPtr(args.Timeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(args.Timeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 			if err != nil {
 				return nil, err
@@ -68,8 +68,8 @@ func serverWaitCommand() *core.Command {
 			server, err = api.WaitForServerInstall(&baremetal.WaitForServerInstallRequest{
 				ServerID:      args.ServerID,
 				Zone:          args.Zone,
-				Timeout:       scw.TimeDurationPtr(args.Timeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(args.Timeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 			if err != nil {
 				return nil, err
@@ -109,8 +109,8 @@ func serverStartBuilder(c *core.Command) *core.Command {
 		return api.WaitForServer(&baremetal.WaitForServerRequest{
 			Zone:          argsI.(*baremetal.StartServerRequest).Zone,
 			ServerID:      respI.(*baremetal.Server).ID,
-			Timeout:       scw.TimeDurationPtr(ServerActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(ServerActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -124,8 +124,8 @@ func serverStopBuilder(c *core.Command) *core.Command {
 		return api.WaitForServer(&baremetal.WaitForServerRequest{
 			Zone:          argsI.(*baremetal.StopServerRequest).Zone,
 			ServerID:      respI.(*baremetal.Server).ID,
-			Timeout:       scw.TimeDurationPtr(ServerActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(ServerActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -141,8 +141,8 @@ func serverRebootBuilder(c *core.Command) *core.Command {
 		return api.WaitForServer(&baremetal.WaitForServerRequest{
 			Zone:          argsI.(*baremetal.RebootServerRequest).Zone,
 			ServerID:      respI.(*baremetal.Server).ID,
-			Timeout:       scw.TimeDurationPtr(ServerActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(ServerActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -100,8 +100,8 @@ func serverCreateBuilder(c *core.Command) *core.Command {
 		return api.WaitForServer(&baremetal.WaitForServerRequest{
 			Zone:          argsI.(*baremetalCreateServerRequestCustom).Zone,
 			ServerID:      respI.(*baremetal.Server).ID,
-			Timeout:       scw.TimeDurationPtr(ServerActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(ServerActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -104,8 +104,8 @@ func serverAddFlexibleIP() *core.Command {
 			return apiFip.WaitForFlexibleIP(&fip.WaitForFlexibleIPRequest{
 				FipID:         flexibleIP.ID,
 				Zone:          flexibleIP.Zone,
-				Timeout:       scw.TimeDurationPtr(flexibleip.FlexibleIPTimeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(flexibleip.FlexibleIPTimeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 		},
 	}
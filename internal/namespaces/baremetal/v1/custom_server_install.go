@@ -56,8 +56,8 @@ func serverInstallBuilder(c *core.Command) *core.Command {
 		return api.WaitForServerInstall(&baremetal.WaitForServerInstallRequest{
 			Zone:          argsI.(*baremetalInstallServerRequestCustom).Zone,
 			ServerID:      respI.(*baremetal.Server).ID,
-			Timeout:       scw.TimeDurationPtr(ServerActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(ServerActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -15,8 +15,8 @@ func serverDeleteBuilder(c *core.Command) *core.Command {
 		server, err := baremetal.NewAPI(core.ExtractClient(ctx)).WaitForServer(&baremetal.WaitForServerRequest{
 			ServerID:      argsI.(*baremetal.DeleteServerRequest).ServerID,
 			Zone:          argsI.(*baremetal.DeleteServerRequest).Zone,
-			Timeout:       scw.TimeDurationPtr(ServerActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(ServerActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			notFoundError := &scw.ResourceNotFoundError{}
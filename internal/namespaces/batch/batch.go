@@ -0,0 +1,335 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/fatih/color"
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-cli/v2/core/human"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-cli/v2/internal/pkg/shlex"
+)
+
+func GetCommands() *core.Commands {
+	human.RegisterMarshalerFunc(stepStatus(""), human.EnumMarshalFunc(stepStatusMarshalSpecs))
+
+	return core.NewCommands(
+		batchRoot(),
+		batchRunCommand(),
+	)
+}
+
+func batchRoot() *core.Command {
+	return &core.Command{
+		Groups:    []string{"utility"},
+		Short:     "Run several commands from a playbook",
+		Long:      `Batch commands run a list of scw commands described in a YAML playbook.`,
+		Namespace: "batch",
+	}
+}
+
+// playbook is the YAML file describing the commands run by batch run.
+type playbook struct {
+	Steps []*playbookStep `json:"steps"`
+}
+
+type playbookStep struct {
+	// Name is used to reference the result of the step in the next ones, default is step<N>.
+	Name string `json:"name"`
+
+	// Command is the scw command to run, it is a template rendered with the results of the previous steps.
+	Command string `json:"command"`
+
+	// Wait runs the command with --wait.
+	Wait bool `json:"wait"`
+
+	// ContinueOnError runs the next steps even if this one fails.
+	ContinueOnError bool `json:"continue_on_error"`
+
+	// When is a template rendered with the results of the previous steps, the step is skipped unless it renders true.
+	When string `json:"when"`
+}
+
+type stepStatus string
+
+const (
+	stepStatusSucceeded = stepStatus("succeeded")
+	stepStatusFailed    = stepStatus("failed")
+	stepStatusSkipped   = stepStatus("skipped")
+)
+
+var stepStatusMarshalSpecs = human.EnumMarshalSpecs{
+	stepStatusSucceeded: &human.EnumMarshalSpec{Attribute: color.FgGreen},
+	stepStatusFailed:    &human.EnumMarshalSpec{Attribute: color.FgRed},
+	stepStatusSkipped:   &human.EnumMarshalSpec{Attribute: color.Faint},
+}
+
+// stepReport is the outcome of a step, the report of batch run lists one for each step.
+type stepReport struct {
+	Name    string      `json:"name"`
+	Command string      `json:"command"`
+	Status  stepStatus  `json:"status"`
+	Error   string      `json:"error,omitempty"`
+	Result  interface{} `json:"result,omitempty"`
+}
+
+// stepNameRegex restricts step names to what can be used in templates, e.g. {{ .steps.create_server.ID }}.
+var stepNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+type batchRunRequest struct {
+	File string
+}
+
+func batchRunCommand() *core.Command {
+	return &core.Command{
+		Short: "Run the commands of a playbook",
+		Long: `Run the steps of a YAML playbook one after the other and print a report of all steps.
+
+Each step runs a scw command in the current process, with the same profile and global flags as batch run.
+Commands and conditions are Go templates that can read the results of the previous steps:
+  - .steps.<name> is the result of a step, e.g. {{ .steps.create_server.ID }}
+  - .status.<name> is the status of a step: succeeded, failed or skipped
+
+Steps accept the following keys:
+  - name: name used to reference the step, default is step<N>
+  - command: the command to run, without the binary name
+  - wait: run the command with --wait
+  - continue_on_error: run the next steps even if this one fails
+  - when: a condition, the step is skipped unless it renders true
+
+The run stops at the first failing step unless continue_on_error is set.
+
+Example of playbook creating a server then a flexible IP attached to it:
+
+  steps:
+    - name: create_server
+      command: instance server create type=DEV1-S image=ubuntu_jammy
+      wait: true
+    - name: create_ip
+      command: instance ip create server={{ .steps.create_server.ID }}
+      when: '{{ eq .status.create_server "succeeded" }}'
+      continue_on_error: true`,
+		Namespace: "batch",
+		Resource:  "run",
+		ArgsType:  reflect.TypeOf(batchRunRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "file",
+				Short:      "Path of the YAML playbook",
+				Required:   true,
+				Positional: true,
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Run a playbook",
+				Raw:   "scw batch run playbook.yaml",
+			},
+		},
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Name", FieldName: "Name"},
+				{Label: "Status", FieldName: "Status"},
+				{Label: "Command", FieldName: "Command"},
+				{Label: "Error", FieldName: "Error"},
+			},
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			request := argsI.(*batchRunRequest)
+
+			content, err := os.ReadFile(request.File)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read playbook: %w", err)
+			}
+
+			p, err := parsePlaybook(content)
+			if err != nil {
+				return nil, err
+			}
+
+			return runPlaybook(ctx, p)
+		},
+	}
+}
+
+// parsePlaybook parses a playbook and checks its steps before any of them is run.
+func parsePlaybook(content []byte) (*playbook, error) {
+	p := &playbook{}
+	err := yaml.Unmarshal(content, p)
+	if err != nil {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("invalid playbook: %w", err),
+			Hint: "A playbook is a YAML document with a list of steps, see 'scw batch run --help'",
+		}
+	}
+	if len(p.Steps) == 0 {
+		return nil, &core.CliError{
+			Err:  errors.New("invalid playbook: no steps"),
+			Hint: "A playbook is a YAML document with a list of steps, see 'scw batch run --help'",
+		}
+	}
+
+	names := map[string]bool{}
+	for i, step := range p.Steps {
+		if step == nil {
+			return nil, invalidStepError(fmt.Sprintf("step%d", i+1), errors.New("step is empty"))
+		}
+		if step.Name == "" {
+			step.Name = fmt.Sprintf("step%d", i+1)
+		}
+		if !stepNameRegex.MatchString(step.Name) {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("invalid step name '%s'", step.Name),
+				Hint: "Step names can only contain letters, numbers and underscores, e.g. create_server",
+			}
+		}
+		if names[step.Name] {
+			return nil, invalidStepError(step.Name, errors.New("another step has the same name"))
+		}
+		names[step.Name] = true
+
+		if strings.TrimSpace(step.Command) == "" {
+			return nil, invalidStepError(step.Name, errors.New("command is missing"))
+		}
+		// Templates are parsed upfront so that a typo does not stop the run halfway.
+		for _, text := range []string{step.Command, step.When} {
+			_, err := newTemplate(step.Name, text)
+			if err != nil {
+				return nil, invalidStepError(step.Name, err)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+func invalidStepError(name string, err error) error {
+	return &core.CliError{
+		Err: fmt.Errorf("invalid step %s: %w", name, err),
+	}
+}
+
+// runPlaybook runs the steps of a playbook and returns their reports.
+func runPlaybook(ctx context.Context, p *playbook) (interface{}, error) {
+	binaryName := core.ExtractBinaryName(ctx)
+	results := map[string]interface{}{}
+	statuses := map[string]stepStatus{}
+	reports := []*stepReport(nil)
+
+	for _, step := range p.Steps {
+		report := &stepReport{Name: step.Name}
+		reports = append(reports, report)
+		data := map[string]interface{}{
+			"steps":  results,
+			"status": statuses,
+		}
+
+		result, err := runStep(ctx, binaryName, step, data, report)
+		results[step.Name] = result
+		statuses[step.Name] = report.Status
+
+		if err == nil {
+			continue
+		}
+		report.Error = err.Error()
+		if _, interrupted := err.(*interactive.InterruptError); interrupted {
+			return nil, err
+		}
+		if !step.ContinueOnError {
+			return nil, &core.CliError{
+				Err:     fmt.Errorf("step %s failed: %w", step.Name, err),
+				Details: reportSummary(reports),
+				Hint:    "Set continue_on_error on the step to run the next steps anyway",
+			}
+		}
+	}
+
+	return reports, nil
+}
+
+// runStep renders the condition and the command of a step and runs the command in-process.
+// It fills the report with the rendered command and the status of the step.
+func runStep(ctx context.Context, binaryName string, step *playbookStep, data map[string]interface{}, report *stepReport) (interface{}, error) {
+	report.Status = stepStatusFailed
+
+	if step.When != "" {
+		condition, err := renderTemplate(step.Name, step.When, data)
+		if err != nil {
+			return nil, err
+		}
+		run, err := strconv.ParseBool(strings.TrimSpace(condition))
+		if err != nil {
+			return nil, fmt.Errorf("condition must render true or false, got '%s'", condition)
+		}
+		if !run {
+			report.Status = stepStatusSkipped
+			return nil, nil
+		}
+	}
+
+	command, err := renderTemplate(step.Name, step.Command, data)
+	if err != nil {
+		return nil, err
+	}
+	report.Command = command
+
+	args, err := shlex.Split(command)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse command: %w", err)
+	}
+	if len(args) > 0 && args[0] == binaryName {
+		args = args[1:]
+	}
+	if step.Wait {
+		args = append(args, "--wait")
+	}
+
+	config := core.BootstrapConfigFromContext(ctx, args)
+	// Results are printed in the report, errors are still printed as they happen.
+	config.Stdout = io.Discard
+	_, result, err := core.Bootstrap(config)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Status = stepStatusSucceeded
+	report.Result = result
+	return result, nil
+}
+
+func newTemplate(name string, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(text)
+}
+
+func renderTemplate(name string, text string, data interface{}) (string, error) {
+	tpl, err := newTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+	buffer := &bytes.Buffer{}
+	err = tpl.Execute(buffer, data)
+	if err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// reportSummary lists the status of the steps that were run.
+func reportSummary(reports []*stepReport) string {
+	lines := make([]string, 0, len(reports))
+	for _, report := range reports {
+		lines = append(lines, fmt.Sprintf("%s: %s", report.Name, report.Status))
+	}
+	return strings.Join(lines, "\n")
+}
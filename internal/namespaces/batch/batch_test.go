@@ -0,0 +1,106 @@
+package batch_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/batch"
+)
+
+type flower struct {
+	ID   string
+	Name string
+}
+
+type flowerCreateRequest struct {
+	Name string
+}
+
+type flowerGetRequest struct {
+	FlowerID string
+}
+
+func testCommands() *core.Commands {
+	commands := batch.GetCommands()
+	commands.Merge(core.NewCommands(
+		&core.Command{
+			Namespace: "test",
+			Resource:  "flower",
+			Verb:      "create",
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name: "name",
+				},
+			},
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(flowerCreateRequest{}),
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				name := argsI.(*flowerCreateRequest).Name
+				return &flower{ID: "flower-" + strings.ReplaceAll(name, " ", "-"), Name: name}, nil
+			},
+		},
+		&core.Command{
+			Namespace: "test",
+			Resource:  "flower",
+			Verb:      "get",
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name:       "flower-id",
+					Positional: true,
+				},
+			},
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(flowerGetRequest{}),
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				id := argsI.(*flowerGetRequest).FlowerID
+				if id != "flower-rose" {
+					return nil, fmt.Errorf("flower %s not found", id)
+				}
+				return &flower{ID: id, Name: "rose"}, nil
+			},
+		},
+	))
+	return commands
+}
+
+func Test_BatchRun(t *testing.T) {
+	t.Run("chain", core.Test(&core.TestConfig{
+		Commands: testCommands(),
+		Cmd:      "scw batch run testdata/playbook-chain.yaml",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("continue on error", core.Test(&core.TestConfig{
+		Commands: testCommands(),
+		Cmd:      "scw batch run testdata/playbook-continue-on-error.yaml -o json",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("stop on error", core.Test(&core.TestConfig{
+		Commands: testCommands(),
+		Cmd:      "scw batch run testdata/playbook-stop-on-error.yaml",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	t.Run("invalid template", core.Test(&core.TestConfig{
+		Commands: testCommands(),
+		Cmd:      "scw batch run testdata/playbook-invalid-template.yaml",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+}
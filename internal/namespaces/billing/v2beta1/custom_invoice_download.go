@@ -26,6 +26,7 @@ type billingDownloadRequest struct {
 }
 
 func invoiceDownloadBuilder(command *core.Command) *core.Command {
+	command.LocalEffects = true
 	command.ArgsType = reflect.TypeOf(billingDownloadRequest{})
 	command.ArgSpecs = core.ArgSpecs{
 		{
@@ -24,6 +24,7 @@ type billingExportRequest struct {
 }
 
 func invoiceExportBuilder(command *core.Command) *core.Command {
+	command.LocalEffects = true
 	command.ArgsType = reflect.TypeOf(billingExportRequest{})
 	command.ArgSpecs = core.ArgSpecs{
 		{
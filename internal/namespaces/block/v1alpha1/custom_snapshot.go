@@ -44,8 +44,8 @@ func snapshotWaitCommand() *core.Command {
 			return block.NewAPI(core.ExtractClient(ctx)).WaitForSnapshot(&block.WaitForSnapshotRequest{
 				Zone:          args.Zone,
 				SnapshotID:    args.SnapshotID,
-				Timeout:       scw.TimeDurationPtr(args.Timeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(args.Timeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 
 				TerminalStatus: args.TerminalStatus,
 			})
@@ -79,8 +79,10 @@ func blockSnapshotCreateBuilder(c *core.Command) *core.Command {
 		resp := respI.(*block.Snapshot)
 
 		return block.NewAPI(core.ExtractClient(ctx)).WaitForSnapshot(&block.WaitForSnapshotRequest{
-			SnapshotID: resp.ID,
-			Zone:       resp.Zone,
+			SnapshotID:    resp.ID,
+			Zone:          resp.Zone,
+			Timeout:       core.WaitTimeout(ctx, nil),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -44,8 +44,8 @@ func volumeWaitCommand() *core.Command {
 			return block.NewAPI(core.ExtractClient(ctx)).WaitForVolume(&block.WaitForVolumeRequest{
 				Zone:          args.Zone,
 				VolumeID:      args.VolumeID,
-				Timeout:       scw.TimeDurationPtr(args.Timeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(args.Timeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 
 				TerminalStatus: args.TerminalStatus,
 			})
@@ -79,8 +79,10 @@ func blockVolumeCreateBuilder(c *core.Command) *core.Command {
 		resp := respI.(*block.Volume)
 
 		return block.NewAPI(core.ExtractClient(ctx)).WaitForVolume(&block.WaitForVolumeRequest{
-			VolumeID: resp.ID,
-			Zone:     resp.Zone,
+			VolumeID:      resp.ID,
+			Zone:          resp.Zone,
+			Timeout:       core.WaitTimeout(ctx, nil),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 
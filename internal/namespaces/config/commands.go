@@ -3,20 +3,27 @@ package config
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/scaleway/scaleway-cli/v2/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-cli/v2/internal/pkg/shlex"
+	"github.com/scaleway/scaleway-cli/v2/internal/profiles"
 	"github.com/scaleway/scaleway-cli/v2/internal/tabwriter"
 	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/scaleway-sdk-go/strcase"
 	"github.com/scaleway/scaleway-sdk-go/validation"
+	"gopkg.in/yaml.v3"
 )
 
 func GetCommands() *core.Commands {
@@ -32,6 +39,7 @@ func GetCommands() *core.Commands {
 		configResetCommand(),
 		configDestroyCommand(),
 		configInfoCommand(),
+		configEnvCommand(),
 		configImportCommand(),
 		configValidateCommand(),
 	)
@@ -74,6 +82,12 @@ func configRoot() *core.Command {
 			The following environment variables are supported:
 
 			` + envVarTable.String() + `
+			A profile can inherit the values it does not set from another profile with the extends key, e.g. extends: base. The default profile is inherited last.
+
+			The secret key of a profile can be the output of a command with the secret_key_command key, e.g. secret_key_command: pass show scw/prod. The command is run when the client is created, set secret_key_cache to process to run it once per process, e.g. in shell mode. Secret keys are never cached on disk.
+
+			A project config file, .scw.yaml or .scw/config.yaml, is looked for from the current directory up to the root directory, e.g. at the root of a repository. It can set profile, default_project_id, default_region, default_zone, output and aliases. Its values have priority over the config files of the home directory but not over environment variables and flags, scw info shows the file in use. Its aliases cannot be named like a command word, e.g. list, or like an alias of the CLI config.
+
 			Read more about the config management engine at https://github.com/scaleway/scaleway-sdk-go/tree/master/scw#scaleway-config
 		`),
 		Namespace: "config",
@@ -176,6 +190,7 @@ func configSetCommand() *core.Command {
 The only allowed attributes are access_key, secret_key, default_organization_id, default_region, default_zone, api_url, insecure`,
 		Namespace:            "config",
 		Resource:             "set",
+		LocalEffects:         true,
 		AllowAnonymousClient: true,
 		ArgsType:             reflect.TypeOf(scw.Profile{}),
 		ArgSpecs: core.ArgSpecs{
@@ -300,7 +315,7 @@ The only allowed attributes are access_key, secret_key, default_organization_id,
 			}
 
 			// Save
-			err = config.SaveTo(configPath)
+			err = profiles.SaveConfig(config, configPath)
 			if err != nil {
 				return nil, err
 			}
@@ -323,6 +338,7 @@ func configUnsetCommand() *core.Command {
 		Short:                `Unset a line from the config file`,
 		Namespace:            "config",
 		Resource:             "unset",
+		LocalEffects:         true,
 		AllowAnonymousClient: true,
 		ArgsType:             reflect.TypeOf(configUnsetArgs{}),
 		ArgSpecs: core.ArgSpecs{
@@ -352,7 +368,7 @@ func configUnsetCommand() *core.Command {
 				return nil, err
 			}
 
-			err = config.SaveTo(configPath)
+			err = profiles.SaveConfig(config, configPath)
 			if err != nil {
 				return nil, err
 			}
@@ -371,6 +387,7 @@ func configDumpCommand() *core.Command {
 	return &core.Command{
 		Groups:               []string{"config"},
 		Short:                `Dump the config file`,
+		Long:                 `Dump the config file, followed by the defaults section of the CLI config, i.e. the values used for the arguments of commands when they are not given.`,
 		Namespace:            "config",
 		Resource:             "dump",
 		AllowAnonymousClient: true,
@@ -383,15 +400,130 @@ func configDumpCommand() *core.Command {
 		},
 		Run: func(ctx context.Context, _ interface{}) (i interface{}, e error) {
 			configPath := core.ExtractConfigPath(ctx)
-			config, err := scw.LoadConfigFromPath(configPath)
+			config, extensions, err := profiles.LoadConfig(configPath)
 			if err != nil {
 				return nil, err
 			}
-			return config, nil
+			defaults := map[string]map[string]string(nil)
+			if cliConfig := core.ExtractCliConfig(ctx); cliConfig != nil {
+				defaults = cliConfig.Defaults
+			}
+			if extensions.IsEmpty() && len(defaults) == 0 {
+				return config, nil
+			}
+			return &configDump{config: config, extensions: extensions, defaults: defaults}, nil
 		},
 	}
 }
 
+// configDump is a config file whose profiles extend other profiles, or that is used with the defaults of the CLI config.
+// It shows the profiles they extend and the values they inherit from them, then the defaults.
+type configDump struct {
+	config     *scw.Config
+	extensions *profiles.Extensions
+	defaults   map[string]map[string]string
+}
+
+// inherited returns the keys of a profile inherited from the profiles it extends, grouped by profile.
+// Keys inherited from the default profile are not listed as all profiles inherit from it.
+func (d *configDump) inherited(profileName string) map[string][]string {
+	_, origins, err := profiles.Resolve(d.config, d.extensions, profileName)
+	if err != nil {
+		return nil
+	}
+	inherited := map[string][]string{}
+	for key, origin := range origins {
+		if origin != profileName && origin != scw.DefaultProfileName {
+			inherited[origin] = append(inherited[origin], key)
+		}
+	}
+	for origin := range inherited {
+		sort.Strings(inherited[origin])
+	}
+	return inherited
+}
+
+func (d *configDump) MarshalHuman() (string, error) {
+	extensions := map[string]*profiles.Extension{scw.DefaultProfileName: &d.extensions.Default}
+	comments := map[string][]string{}
+	for profileName := range d.config.Profiles {
+		extensions[profileName] = d.extensions.Get(profileName)
+		if d.extensions.Get(profileName).Extends == "" {
+			continue
+		}
+		inherited := d.inherited(profileName)
+		origins := make([]string, 0, len(inherited))
+		for origin := range inherited {
+			origins = append(origins, origin)
+		}
+		sort.Strings(origins)
+		for _, origin := range origins {
+			comments[profileName] = append(comments[profileName], fmt.Sprintf("inherited from %s: %s", origin, strings.Join(inherited[origin], ", ")))
+		}
+	}
+	content, err := profiles.SetExtensions([]byte(d.config.String()), extensions, comments)
+	if err != nil {
+		return "", err
+	}
+	dump := string(content)
+	if len(d.defaults) > 0 {
+		content := bytes.Buffer{}
+		encoder := yaml.NewEncoder(&content)
+		encoder.SetIndent(2)
+		err := encoder.Encode(map[string]any{"defaults": d.defaults})
+		if err != nil {
+			return "", err
+		}
+		dump += "\n# defaults of the CLI config\n" + content.String()
+	}
+	return dump, nil
+}
+
+func (d *configDump) MarshalJSON() ([]byte, error) {
+	content, err := json.Marshal(d.config)
+	if err != nil {
+		return nil, err
+	}
+	dump := map[string]any{}
+	err = json.Unmarshal(content, &dump)
+	if err != nil {
+		return nil, err
+	}
+
+	addExtensionKeys(dump, &d.extensions.Default)
+	dumpProfiles, _ := dump["profiles"].(map[string]any)
+	for profileName, profile := range dumpProfiles {
+		addExtensionKeys(profile.(map[string]any), d.extensions.Get(profileName))
+		if d.extensions.Get(profileName).Extends == "" {
+			continue
+		}
+		inherited := map[string]string{}
+		for origin, keys := range d.inherited(profileName) {
+			for _, key := range keys {
+				inherited[key] = origin
+			}
+		}
+		profile.(map[string]any)["inherited"] = inherited
+	}
+	if len(d.defaults) > 0 {
+		dump["defaults"] = d.defaults
+	}
+	return json.Marshal(dump)
+}
+
+// addExtensionKeys adds the keys of a profile extension to the JSON of a profile.
+func addExtensionKeys(profile map[string]any, extension *profiles.Extension) {
+	for key, value := range map[string]string{
+		"extends":            extension.Extends,
+		"secret_key_command": extension.SecretKeyCommand,
+		"secret_key_cache":   extension.SecretKeyCache,
+	} {
+		if value != "" {
+			profile[key] = value
+		}
+	}
+}
+
 func configProfileCommand() *core.Command {
 	return &core.Command{
 		Groups:               []string{"config"},
@@ -414,6 +546,7 @@ func configDeleteProfileCommand() *core.Command {
 		Namespace:            "config",
 		Resource:             "profile",
 		Verb:                 "delete",
+		LocalEffects:         true,
 		AllowAnonymousClient: true,
 		ArgsType:             reflect.TypeOf(configDeleteProfileArgs{}),
 		ArgSpecs: core.ArgSpecs{
@@ -426,16 +559,19 @@ func configDeleteProfileCommand() *core.Command {
 		Run: func(ctx context.Context, argsI interface{}) (i interface{}, e error) {
 			profileName := argsI.(*configDeleteProfileArgs).Name
 			configPath := core.ExtractConfigPath(ctx)
-			config, err := scw.LoadConfigFromPath(configPath)
+			config, extensions, err := profiles.LoadConfig(configPath)
 			if err != nil {
 				return nil, err
 			}
+			if children := extensions.ExtendedBy(profileName); len(children) > 0 {
+				return nil, extendedProfileError(profileName, children)
+			}
 			if _, exists := config.Profiles[profileName]; exists {
 				delete(config.Profiles, profileName)
 			} else {
 				return nil, unknownProfileError(profileName)
 			}
-			err = config.SaveTo(configPath)
+			err = profiles.SaveConfig(config, configPath)
 			if err != nil {
 				return nil, err
 			}
@@ -459,6 +595,7 @@ func configActivateProfileCommand() *core.Command {
 		Namespace:            "config",
 		Resource:             "profile",
 		Verb:                 "activate",
+		LocalEffects:         true,
 		AllowAnonymousClient: true,
 		ArgsType:             reflect.TypeOf(configActiveProfileArgs{}),
 		ArgSpecs: core.ArgSpecs{
@@ -486,7 +623,7 @@ func configActivateProfileCommand() *core.Command {
 				config.ActiveProfile = &profileName
 			}
 
-			err = config.SaveTo(configPath)
+			err = profiles.SaveConfig(config, configPath)
 			if err != nil {
 				return nil, err
 			}
@@ -507,6 +644,7 @@ func configResetCommand() *core.Command {
 		Short:                `Reset the config`,
 		Namespace:            "config",
 		Resource:             "reset",
+		LocalEffects:         true,
 		AllowAnonymousClient: true,
 		ArgsType:             reflect.TypeOf(configResetArgs{}),
 		Run: func(_ context.Context, _ interface{}) (i interface{}, e error) {
@@ -535,7 +673,9 @@ func configDestroyCommand() *core.Command {
 		Short:                `Destroy the config file`,
 		Namespace:            "config",
 		Resource:             "destroy",
+		LocalEffects:         true,
 		AllowAnonymousClient: true,
+		Destructive:          true,
 		ArgsType:             reflect.TypeOf(configDestroyArgs{}),
 		Run: func(ctx context.Context, _ interface{}) (i interface{}, e error) {
 			configPath := core.ExtractConfigPath(ctx)
@@ -579,7 +719,7 @@ func configInfoCommand() *core.Command {
 			},
 		},
 		Run: func(ctx context.Context, _ interface{}) (i interface{}, e error) {
-			config, err := scw.LoadConfigFromPath(core.ExtractConfigPath(ctx))
+			config, extensions, err := profiles.LoadConfig(core.ExtractConfigPath(ctx))
 			if err != nil {
 				return nil, err
 			}
@@ -597,8 +737,8 @@ func configInfoCommand() *core.Command {
 			}
 
 			profileName := core.ExtractProfileName(ctx)
-			// use config.GetProfile instead of getProfile as we want the profile merged with the default
-			profile, err := config.GetProfile(profileName)
+			// use profiles.Resolve instead of getProfile as we want the profile merged with the ones it extends and the default
+			profile, profileOrigins, err := profiles.Resolve(config, extensions, profileName)
 			if err != nil {
 				return nil, err
 			}
@@ -606,11 +746,31 @@ func configInfoCommand() *core.Command {
 			profile = scw.MergeProfiles(profile, profileEnv)
 
 			values := map[string]any{}
+			origins := map[string]string{}
 			for _, key := range getProfileKeys() {
 				value, err := getProfileValue(profile, key)
 				if err == nil && value != nil {
 					values[key] = value
 				}
+				if origin, exists := profileOrigins[strings.ReplaceAll(key, "-", "_")]; exists {
+					origins[key] = origin
+				}
+			}
+			for _, key := range overridedVariables {
+				origins[key] = "env"
+			}
+			// The secret_key_command is not run, it is displayed unless the environment sets the secret key
+			extension, extensionOrigin, err := profiles.SecretKeyCommand(config, extensions, profileName)
+			if err != nil {
+				return nil, err
+			}
+			if extension != nil && profileEnv.SecretKey == nil {
+				values["secret-key-command"] = extension.SecretKeyCommand
+				origins["secret-key-command"] = extensionOrigin
+				if extension.SecretKeyCache != "" {
+					values["secret-key-cache"] = extension.SecretKeyCache
+					origins["secret-key-cache"] = extensionOrigin
+				}
 			}
 
 			if len(overridedVariables) > 0 {
@@ -622,15 +782,201 @@ func configInfoCommand() *core.Command {
 				ConfigPath  string
 				ProfileName string
 				Profile     map[string]any
+				// Origins are the profiles the values come from, or env.
+				Origins map[string]string
 			}{
 				ConfigPath:  core.ExtractConfigPath(ctx),
 				ProfileName: core.ExtractProfileName(ctx),
 				Profile:     values,
+				Origins:     origins,
 			}, nil
 		},
 	}
 }
 
+// envFormats are the formats of configEnvCommand, the key is the value of the shell argument.
+var envFormats = map[string]func(key string, value string) string{
+	"bash": func(key string, value string) string {
+		return fmt.Sprintf("export %s='%s'", key, strings.ReplaceAll(value, "'", `'\''`))
+	},
+	"zsh": func(key string, value string) string {
+		return fmt.Sprintf("export %s='%s'", key, strings.ReplaceAll(value, "'", `'\''`))
+	},
+	"fish": func(key string, value string) string {
+		return fmt.Sprintf("set -gx %s '%s'", key, strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(value))
+	},
+	"powershell": func(key string, value string) string {
+		return fmt.Sprintf("$Env:%s = '%s'", key, strings.ReplaceAll(value, "'", "''"))
+	},
+	"dotenv": func(key string, value string) string {
+		return fmt.Sprintf(`%s="%s"`, key, strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value))
+	},
+}
+
+// envShellFormats maps the names of shells found in the SHELL environment variable to the format of their variables.
+var envShellFormats = map[string]string{
+	"pwsh": "powershell",
+}
+
+// configEnvCommand prints the values of a profile as environment variables
+func configEnvCommand() *core.Command {
+	type configEnvArgs struct {
+		Shell string
+		Aws   bool
+	}
+
+	shells := make([]string, 0, len(envFormats))
+	for shell := range envFormats {
+		shells = append(shells, shell)
+	}
+	sort.Strings(shells)
+
+	return &core.Command{
+		Groups: []string{"config"},
+		Short:  `Export the current profile as environment variables`,
+		Long: `Print the values of the current profile as environment variables, e.g. to give credentials to Terraform or docker builds.
+The values are the ones used by the CLI: the profile with the values it inherits, the project config file and the environment.
+With aws=true, the AWS_* variables used by S3-compatible tools, e.g. the AWS CLI, are also printed for the Object Storage of the default region.`,
+		Namespace:            "config",
+		Resource:             "env",
+		AllowAnonymousClient: true,
+		ArgsType:             reflect.TypeOf(configEnvArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "shell",
+				Short:      "Syntax of the variables",
+				EnumValues: shells,
+				Default: func(ctx context.Context) (string, string) {
+					shell := strings.TrimSuffix(filepath.Base(core.ExtractEnv(ctx, "SHELL")), ".exe")
+					if format, exists := envShellFormats[shell]; exists {
+						shell = format
+					}
+					if _, exists := envFormats[shell]; !exists {
+						shell = "bash"
+					}
+					return shell, "shell of the SHELL environment variable, bash if unknown"
+				},
+			},
+			{
+				Name:  "aws",
+				Short: "Print the AWS_* variables of S3-compatible tools",
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Export the current profile in bash or zsh",
+				Raw:   `eval "$(scw config env)"`,
+			},
+			{
+				Short: "Export the profile 'prod' with the variables of the AWS CLI in fish",
+				Raw:   "scw -p prod config env shell=fish aws=true | source",
+			},
+			{
+				Short: "Export the current profile in PowerShell",
+				Raw:   "scw config env shell=powershell | Invoke-Expression",
+			},
+			{
+				Short: "Write the current profile in a dotenv file",
+				Raw:   "scw config env shell=dotenv > .env",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Get info about the current profile",
+				Command: "scw config info",
+			},
+		},
+		Run: func(ctx context.Context, argsI interface{}) (i interface{}, e error) {
+			args := argsI.(*configEnvArgs)
+
+			format := envFormats[args.Shell]
+
+			profile, err := activeProfile(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			variables := [][2]string(nil)
+			for _, variable := range []struct {
+				key   string
+				value *string
+			}{
+				{scw.ScwAccessKeyEnv, profile.AccessKey},
+				{scw.ScwSecretKeyEnv, profile.SecretKey},
+				{scw.ScwDefaultOrganizationIDEnv, profile.DefaultOrganizationID},
+				{scw.ScwDefaultProjectIDEnv, profile.DefaultProjectID},
+				{scw.ScwDefaultRegionEnv, profile.DefaultRegion},
+				{scw.ScwDefaultZoneEnv, profile.DefaultZone},
+				{scw.ScwAPIURLEnv, profile.APIURL},
+			} {
+				if variable.value != nil && *variable.value != "" {
+					variables = append(variables, [2]string{variable.key, *variable.value})
+				}
+			}
+			if profile.Insecure != nil && *profile.Insecure {
+				variables = append(variables, [2]string{scw.ScwInsecureEnv, "true"})
+			}
+
+			if args.Aws {
+				region := scw.RegionFrPar.String()
+				if profile.DefaultRegion != nil && *profile.DefaultRegion != "" {
+					region = *profile.DefaultRegion
+				}
+				if profile.AccessKey != nil {
+					variables = append(variables, [2]string{"AWS_ACCESS_KEY_ID", *profile.AccessKey})
+				}
+				if profile.SecretKey != nil {
+					variables = append(variables, [2]string{"AWS_SECRET_ACCESS_KEY", *profile.SecretKey})
+				}
+				variables = append(variables,
+					[2]string{"AWS_DEFAULT_REGION", region},
+					[2]string{"AWS_REGION", region},
+					[2]string{"AWS_ENDPOINT_URL_S3", "https://s3." + region + ".scw.cloud"},
+				)
+			}
+
+			lines := make([]string, 0, len(variables))
+			for _, variable := range variables {
+				lines = append(lines, format(variable[0], variable[1]))
+			}
+			return core.RawResult(strings.Join(lines, "\n") + "\n"), nil
+		},
+	}
+}
+
+// activeProfile returns the values of the current profile used by the CLI.
+// The profile of the config file, with the values it inherits, is merged with the project config file and the environment.
+// Its secret_key_command is run unless the environment sets the secret key.
+func activeProfile(ctx context.Context) (*scw.Profile, error) {
+	config, extensions, err := profiles.LoadConfig(core.ExtractConfigPath(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	profileName := core.ExtractProfileName(ctx)
+	profile, _, err := profiles.Resolve(config, extensions, profileName)
+	if err != nil {
+		return nil, err
+	}
+	profile = scw.MergeProfiles(profile, core.ExtractProjectConfig(ctx).ScwProfile(), scw.LoadEnvProfile())
+
+	if profile.SecretKey == nil {
+		extension, origin, err := profiles.SecretKeyCommand(config, extensions, profileName)
+		if err != nil {
+			return nil, err
+		}
+		if extension != nil {
+			secretKey, err := core.SecretKeyFromCommand(ctx, origin, extension)
+			if err != nil {
+				return nil, err
+			}
+			profile.SecretKey = &secretKey
+		}
+	}
+
+	return profile, nil
+}
+
 // configImportCommand imports an external config
 func configImportCommand() *core.Command {
 	type configImportArgs struct {
@@ -642,6 +988,7 @@ func configImportCommand() *core.Command {
 		Short:                "Import configurations from another file",
 		Namespace:            "config",
 		Resource:             "import",
+		LocalEffects:         true,
 		AllowAnonymousClient: true,
 		ArgsType:             reflect.TypeOf(configImportArgs{}),
 		ArgSpecs: core.ArgSpecs{
@@ -685,7 +1032,7 @@ func configImportCommand() *core.Command {
 				}
 			}
 
-			err = currentConfig.SaveTo(configPath)
+			err = profiles.SaveConfig(currentConfig, configPath)
 			if err != nil {
 				return nil, fmt.Errorf("failed to save updated configuration: %v", err)
 			}
@@ -710,6 +1057,7 @@ It performs the following checks:
 	- YAML syntax correctness: It checks whether your config file is a valid YAML file.
 	- Field validity: It checks whether the fields present in the config file are valid and expected fields. This includes fields like AccessKey, SecretKey, DefaultOrganizationID, DefaultProjectID, DefaultRegion, DefaultZone, and APIURL.
 	- Field values: For each of the fields mentioned above, it checks whether the value assigned to it is valid. For example, it checks if the AccessKey and SecretKey are non-empty and meet the format expectations.
+	- Secret key command: It checks that a profile does not set both secret_key and secret_key_command, that the command can be parsed and that secret_key_cache is process. The command is not run.
 
 The command goes through each profile present in the config file and validates it.`,
 		Namespace:            "config",
@@ -718,7 +1066,7 @@ The command goes through each profile present in the config file and validates i
 		ArgsType:             reflect.TypeOf(configValidateArgs{}),
 		Run: func(ctx context.Context, _ interface{}) (i interface{}, e error) {
 			configPath := core.ExtractConfigPath(ctx)
-			config, err := scw.LoadConfigFromPath(configPath)
+			config, extensions, err := profiles.LoadConfig(configPath)
 			if err != nil {
 				return nil, err
 			}
@@ -728,12 +1076,32 @@ The command goes through each profile present in the config file and validates i
 			if err != nil {
 				return nil, err
 			}
+			err = validateSecretKeyCommand(scw.DefaultProfileName, &config.Profile, extensions.Get(scw.DefaultProfileName))
+			if err != nil {
+				return nil, err
+			}
 			// validate the remaining profiles
-			for _, profile := range config.Profiles {
+			profileNames := make([]string, 0, len(config.Profiles))
+			for profileName, profile := range config.Profiles {
 				err = validateProfile(profile)
 				if err != nil {
 					return nil, err
 				}
+				profileNames = append(profileNames, profileName)
+			}
+			sort.Strings(profileNames)
+			for _, profileName := range profileNames {
+				err = validateSecretKeyCommand(profileName, config.Profiles[profileName], extensions.Get(profileName))
+				if err != nil {
+					return nil, err
+				}
+			}
+			// validate the profiles they extend
+			for _, profileName := range profileNames {
+				_, err = profiles.Chain(config, extensions, profileName)
+				if err != nil {
+					return nil, invalidExtendsError(err)
+				}
 			}
 
 			return &core.SuccessResult{
@@ -820,6 +1188,26 @@ func validateProfile(profile *scw.Profile) error {
 	return validateAPIURL(profile)
 }
 
+// validateSecretKeyCommand validates the secret_key_command of a profile without running it.
+func validateSecretKeyCommand(profileName string, profile *scw.Profile, extension *profiles.Extension) error {
+	if extension.SecretKeyCommand == "" {
+		if extension.SecretKeyCache != "" {
+			return secretKeyCacheWithoutCommandError(profileName)
+		}
+		return nil
+	}
+	if profile.SecretKey != nil {
+		return secretKeyConflictError(profileName)
+	}
+	if words, err := shlex.Split(extension.SecretKeyCommand); err != nil || len(words) == 0 {
+		return core.InvalidSecretKeyCommandError(extension.SecretKeyCommand)
+	}
+	if extension.SecretKeyCache != "" && !slices.Contains(profiles.SecretKeyCaches, extension.SecretKeyCache) {
+		return core.InvalidSecretKeyCacheError(extension.SecretKeyCache)
+	}
+	return nil
+}
+
 func validateAccessKey(profile *scw.Profile) error {
 	if profile.AccessKey != nil {
 		if *profile.AccessKey == "" {
@@ -1,9 +1,12 @@
 package config_test
 
 import (
+	"fmt"
 	"os"
+	"os/exec"
 	"path"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/alecthomas/assert"
@@ -205,6 +208,17 @@ func Test_ConfigDeleteProfileCommand(t *testing.T) {
 		),
 		TmpHomeDir: true,
 	}))
+
+	t.Run("Extended Profile", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncCreateExtendedConfig(),
+		Cmd:        "scw config profile delete base",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
 }
 
 func Test_ConfigDumpCommand(t *testing.T) {
@@ -218,6 +232,48 @@ func Test_ConfigDumpCommand(t *testing.T) {
 		),
 		TmpHomeDir: true,
 	}))
+
+	t.Run("Extends", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncCreateExtendedConfig(),
+		Cmd:        "scw config dump",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("Defaults", core.Test(&core.TestConfig{
+		Commands: config.GetCommands(),
+		BeforeFunc: core.BeforeFuncCombine(
+			beforeFuncWriteConfigFile(extendedConfig),
+			func(ctx *core.BeforeFuncCtx) error {
+				return os.WriteFile(path.Join(ctx.OverrideEnv["HOME"], ".config", "scw", "cli.yaml"), []byte(`defaults:
+  instance.server.create:
+    type: PRO2-S
+    tags.0: team-x
+`), 0o600)
+			},
+		),
+		Cmd: "scw config dump",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("Secret key command", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncWriteConfigFile(secretKeyCommandConfig),
+		Cmd:        "scw config dump",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
 }
 
 func Test_ConfigDestroyCommand(t *testing.T) {
@@ -310,6 +366,104 @@ func Test_ConfigInfoCommand(t *testing.T) {
 		),
 		TmpHomeDir: true,
 	}))
+
+	t.Run("Extends", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncCreateExtendedConfig(),
+		Cmd:        "scw -p prod config info",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGoldenAndReplacePatterns(configPathReplacements...),
+		),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("Secret key command", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncWriteConfigFile(secretKeyCommandConfig),
+		Cmd:        "scw -p staging config info",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGoldenAndReplacePatterns(configPathReplacements...),
+		),
+		TmpHomeDir: true,
+	}))
+}
+
+func Test_ConfigEnvCommand(t *testing.T) {
+	t.Run("Simple", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncCreateFullConfig(),
+		Cmd:        "scw config env",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("Shell from environment", core.Test(&core.TestConfig{
+		Commands:    config.GetCommands(),
+		BeforeFunc:  beforeFuncCreateFullConfig(),
+		Cmd:         "scw -p p1 config env",
+		OverrideEnv: map[string]string{"SHELL": "/usr/bin/fish"},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("PowerShell from environment", core.Test(&core.TestConfig{
+		Commands:    config.GetCommands(),
+		BeforeFunc:  beforeFuncCreateFullConfig(),
+		Cmd:         "scw -p p1 config env",
+		OverrideEnv: map[string]string{"SHELL": "/usr/bin/pwsh"},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
+
+	for _, shell := range []string{"powershell", "dotenv"} {
+		t.Run(shell, core.Test(&core.TestConfig{
+			Commands:   config.GetCommands(),
+			BeforeFunc: beforeFuncCreateFullConfig(),
+			Cmd:        "scw config env shell=" + shell,
+			Check: core.TestCheckCombine(
+				core.TestCheckExitCode(0),
+				core.TestCheckGolden(),
+			),
+			TmpHomeDir: true,
+		}))
+	}
+
+	t.Run("AWS", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncCreateExtendedConfig(),
+		Cmd:        "scw -p prod config env aws=true",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("Secret key command", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncWriteConfigFile(secretKeyCommandConfig),
+		Cmd:        "scw -p staging config env",
+		OverrideExec: func(_ *core.ExecFuncCtx, cmd *exec.Cmd) (int, error) {
+			_, err := fmt.Fprintln(cmd.Stdout, "22222222-2222-2222-2222-222222222222")
+			return 0, err
+		},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
 }
 
 func Test_ConfigImportCommand(t *testing.T) {
@@ -379,6 +533,86 @@ func Test_ConfigValidateCommand(t *testing.T) {
 		),
 		TmpHomeDir: true,
 	}))
+	t.Run("Extends cycle", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncWriteConfigFile(extendedConfig + "    extends: prod\n"),
+		Cmd:        "scw config validate",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("Secret key command", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncWriteConfigFile(secretKeyCommandConfig),
+		Cmd:        "scw config validate",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
+	t.Run("Secret key and secret key command", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncWriteConfigFile(secretKeyCommandConfig + "    secret_key: 22222222-2222-2222-2222-222222222222\n    secret_key_command: pass show scw/staging\n"),
+		Cmd:        "scw config validate",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
+	t.Run("Invalid secret key cache", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncWriteConfigFile(strings.Replace(secretKeyCommandConfig, "cache: process", "cache: session", 1)),
+		Cmd:        "scw config validate",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(1),
+			core.TestCheckGolden(),
+		),
+		TmpHomeDir: true,
+	}))
+}
+
+func Test_ConfigExtends(t *testing.T) {
+	t.Run("Set keeps extends", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncCreateExtendedConfig(),
+		Cmd:        "scw -p prod config set default-region=nl-ams",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			func(t *testing.T, ctx *core.CheckFuncCtx) {
+				t.Helper()
+				content, err := os.ReadFile(path.Join(ctx.OverrideEnv["HOME"], ".config", "scw", "config.yaml"))
+				require.NoError(t, err)
+				assert.Contains(t, string(content), "  prod:\n    extends: base\n")
+			},
+			checkConfig(func(t *testing.T, config *scw.Config) {
+				t.Helper()
+				assert.Equal(t, "nl-ams", *config.Profiles["prod"].DefaultRegion)
+			}),
+		),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("Set keeps secret key command", core.Test(&core.TestConfig{
+		Commands:   config.GetCommands(),
+		BeforeFunc: beforeFuncWriteConfigFile(secretKeyCommandConfig),
+		Cmd:        "scw -p prod config set default-region=nl-ams",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			func(t *testing.T, ctx *core.CheckFuncCtx) {
+				t.Helper()
+				content, err := os.ReadFile(path.Join(ctx.OverrideEnv["HOME"], ".config", "scw", "config.yaml"))
+				require.NoError(t, err)
+				assert.Contains(t, string(content), "  prod:\n    secret_key_command: pass show scw/prod\n    secret_key_cache: process\n")
+				assert.Contains(t, string(content), "  staging:\n    extends: prod\n")
+			},
+		),
+		TmpHomeDir: true,
+	}))
 }
 
 func checkConfig(f func(t *testing.T, config *scw.Config)) core.TestCheck {
@@ -404,6 +638,55 @@ func beforeFuncCreateConfigFile(c *scw.Config) core.BeforeFunc {
 	}
 }
 
+func beforeFuncWriteConfigFile(content string) core.BeforeFunc {
+	return func(ctx *core.BeforeFuncCtx) error {
+		scwDir := path.Join(ctx.OverrideEnv["HOME"], ".config", "scw")
+		err := os.MkdirAll(scwDir, 0o755)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(path.Join(scwDir, "config.yaml"), []byte(content), 0o600)
+	}
+}
+
+// extendedConfig has a prod profile inheriting the credentials of the base profile.
+const extendedConfig = `access_key: SCWXXXXXXXXXXXXXXXXX
+secret_key: 11111111-1111-1111-1111-111111111111
+default_organization_id: 11111111-1111-1111-1111-111111111111
+default_region: fr-par
+default_zone: fr-par-1
+profiles:
+  prod:
+    extends: base
+    default_project_id: 33333333-3333-3333-3333-333333333333
+    default_zone: nl-ams-1
+  base:
+    access_key: SCWBASEXXXXXXXXXXXXX
+    secret_key: 22222222-2222-2222-2222-222222222222
+    api_url: https://base-mock-api-url.com
+`
+
+// secretKeyCommandConfig has a staging profile inheriting the secret_key_command of the prod profile.
+const secretKeyCommandConfig = `access_key: SCWXXXXXXXXXXXXXXXXX
+secret_key: 11111111-1111-1111-1111-111111111111
+default_organization_id: 11111111-1111-1111-1111-111111111111
+default_region: fr-par
+default_zone: fr-par-1
+profiles:
+  prod:
+    access_key: SCWPRODXXXXXXXXXXXXX
+    secret_key_command: pass show scw/prod
+    secret_key_cache: process
+  staging:
+    extends: prod
+    default_zone: fr-par-2
+`
+
+func beforeFuncCreateExtendedConfig() core.BeforeFunc {
+	return beforeFuncWriteConfigFile(extendedConfig)
+}
+
 func beforeFuncCreateFullConfig() core.BeforeFunc {
 	return beforeFuncCreateConfigFile(&scw.Config{
 		Profile: scw.Profile{
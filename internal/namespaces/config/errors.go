@@ -6,6 +6,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/scaleway/scaleway-cli/v2/core"
 )
@@ -21,3 +22,31 @@ func unknownProfileError(profileName string) *core.CliError {
 		Err: fmt.Errorf("no profile named %s", profileName),
 	}
 }
+
+func extendedProfileError(profileName string, children []string) *core.CliError {
+	return &core.CliError{
+		Err:  fmt.Errorf("profile %s is extended by %s", profileName, strings.Join(children, ", ")),
+		Hint: "Remove the extends key of these profiles in the config file before deleting this profile",
+	}
+}
+
+func invalidExtendsError(err error) *core.CliError {
+	return &core.CliError{
+		Err:  err,
+		Hint: "The extends key of a profile must be the name of another profile of the config file",
+	}
+}
+
+func secretKeyConflictError(profileName string) *core.CliError {
+	return &core.CliError{
+		Err:  fmt.Errorf("profile %s sets both secret_key and secret_key_command", profileName),
+		Hint: "Remove one of these keys from the profile in the config file",
+	}
+}
+
+func secretKeyCacheWithoutCommandError(profileName string) *core.CliError {
+	return &core.CliError{
+		Err:  fmt.Errorf("profile %s sets secret_key_cache without secret_key_command", profileName),
+		Hint: "secret_key_cache is how long the output of the secret_key_command of the same profile is kept",
+	}
+}
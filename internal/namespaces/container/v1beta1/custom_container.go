@@ -35,8 +35,8 @@ func waitForContainer(ctx context.Context, _, respI interface{}) (interface{}, e
 	return api.WaitForContainer(&container.WaitForContainerRequest{
 		ContainerID:   c.ID,
 		Region:        c.Region,
-		Timeout:       scw.TimeDurationPtr(containerDeployTimeout),
-		RetryInterval: core.DefaultRetryInterval,
+		Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(containerDeployTimeout)),
+		RetryInterval: core.WaitRetryInterval(ctx),
 	})
 }
 
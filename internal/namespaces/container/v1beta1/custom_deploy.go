@@ -56,7 +56,9 @@ func containerDeployCommand() *core.Command {
 		Namespace: "container",
 		Resource:  "deploy",
 		Groups:    []string{"workflow"},
-		ArgsType:  reflect.TypeOf(containerDeployRequest{}),
+		// The image is built and pushed with the local Docker daemon.
+		LocalEffects: true,
+		ArgsType:     reflect.TypeOf(containerDeployRequest{}),
 		ArgSpecs: core.ArgSpecs{
 			{
 				Name:  "name",
@@ -406,8 +408,8 @@ func DeployStepCreateContainer(t *tasks.Task, data *DeployStepPushImageResponse)
 	targetContainer, err = data.API.WaitForContainer(&container.WaitForContainerRequest{
 		Region:        data.Args.Region,
 		ContainerID:   targetContainer.ID,
-		Timeout:       scw.TimeDurationPtr(12*time.Minute + 30*time.Second),
-		RetryInterval: core.DefaultRetryInterval,
+		Timeout:       core.WaitTimeout(t.Ctx, scw.TimeDurationPtr(12*time.Minute+30*time.Second)),
+		RetryInterval: core.WaitRetryInterval(t.Ctx),
 	}, scw.WithContext(t.Ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to deploy container: %w", err)
@@ -436,8 +438,8 @@ func DeployStepDeployContainer(t *tasks.Task, data *DeployStepCreateContainerRes
 	targetContainer, err = data.API.WaitForContainer(&container.WaitForContainerRequest{
 		Region:        data.Args.Region,
 		ContainerID:   targetContainer.ID,
-		Timeout:       scw.TimeDurationPtr(12*time.Minute + 30*time.Second),
-		RetryInterval: core.DefaultRetryInterval,
+		Timeout:       core.WaitTimeout(t.Ctx, scw.TimeDurationPtr(12*time.Minute+30*time.Second)),
+		RetryInterval: core.WaitRetryInterval(t.Ctx),
 	}, scw.WithContext(t.Ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to deploy container: %w", err)
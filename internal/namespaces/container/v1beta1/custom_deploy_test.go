@@ -142,6 +142,16 @@ func Test_Deploy(t *testing.T) {
 		),
 		DisableParallel: true,
 	}))
+
+	// The image would be built and pushed with the local Docker daemon, which --dry-run cannot intercept.
+	t.Run("Dry run", core.Test(&core.TestConfig{
+		Commands: commands,
+		Cmd:      fmt.Sprintf("scw container deploy name=%s build-source=%s port=80 --dry-run", appName+"-dr", simplePath),
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
 }
 
 func testDeleteContainersNamespaceAfter(appName string) func(*core.AfterFuncCtx) error {
@@ -33,8 +33,8 @@ func waitForContainerNamespace(ctx context.Context, _, respI interface{}) (inter
 	return api.WaitForNamespace(&container.WaitForNamespaceRequest{
 		NamespaceID:   ns.ID,
 		Region:        ns.Region,
-		Timeout:       scw.TimeDurationPtr(containerNamespaceActionTimeout),
-		RetryInterval: core.DefaultRetryInterval,
+		Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(containerNamespaceActionTimeout)),
+		RetryInterval: core.WaitRetryInterval(ctx),
 	})
 }
 
@@ -59,8 +59,8 @@ func containerNamespaceDeleteBuilder(c *core.Command) *core.Command {
 		_, err := api.WaitForNamespace(&container.WaitForNamespaceRequest{
 			NamespaceID:   req.NamespaceID,
 			Region:        req.Region,
-			Timeout:       scw.TimeDurationPtr(containerNamespaceActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(containerNamespaceActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			if core.IsNotFoundError(err) {
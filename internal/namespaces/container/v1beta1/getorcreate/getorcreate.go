@@ -3,6 +3,7 @@ package getorcreate
 import (
 	"context"
 
+	"github.com/scaleway/scaleway-cli/v2/core"
 	container "github.com/scaleway/scaleway-sdk-go/api/container/v1beta1"
 	"github.com/scaleway/scaleway-sdk-go/api/registry/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -40,8 +41,10 @@ func Namespace(ctx context.Context, api *container.API, region scw.Region, name
 	}
 
 	namespace, err = api.WaitForNamespace(&container.WaitForNamespaceRequest{
-		Region:      region,
-		NamespaceID: namespace.ID,
+		Region:        region,
+		NamespaceID:   namespace.ID,
+		Timeout:       core.WaitTimeout(ctx, nil),
+		RetryInterval: core.WaitRetryInterval(ctx),
 	}, scw.WithContext(ctx))
 	if err != nil {
 		return nil, err
@@ -32,6 +32,7 @@ func feedbackBugCommand() *core.Command {
 		Long:                 `Send a bug-report to the Scaleway CLI team.`,
 		Namespace:            "feedback",
 		Resource:             `bug`,
+		LocalEffects:         true,
 		ArgsType:             reflect.TypeOf(struct{}{}),
 		ArgSpecs:             core.ArgSpecs{},
 		AllowAnonymousClient: true,
@@ -60,6 +61,7 @@ func feedbackFeatureRequestCommand() *core.Command {
 		Long:                 `Send a feature request to the Scaleway CLI team.`,
 		Namespace:            "feedback",
 		Resource:             `feature`,
+		LocalEffects:         true,
 		ArgsType:             reflect.TypeOf(struct{}{}),
 		ArgSpecs:             core.ArgSpecs{},
 		AllowAnonymousClient: true,
@@ -32,8 +32,8 @@ func createIPBuilder(c *core.Command) *core.Command {
 		return api.WaitForFlexibleIP(&flexibleip.WaitForFlexibleIPRequest{
 			FipID:         getResp.ID,
 			Zone:          getResp.Zone,
-			Timeout:       scw.TimeDurationPtr(FlexibleIPTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(FlexibleIPTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 	return c
@@ -32,7 +32,9 @@ func functionDeploy() *core.Command {
 		Namespace: "function",
 		Resource:  "deploy",
 		Groups:    []string{"workflow"},
-		ArgsType:  reflect.TypeOf(functionDeployRequest{}),
+		// The code is read from a local zip file and uploaded to a presigned URL.
+		LocalEffects: true,
+		ArgsType:     reflect.TypeOf(functionDeployRequest{}),
 		ArgSpecs: []*core.ArgSpec{
 			{
 				Name:  "namespace-id",
@@ -141,8 +143,10 @@ func DeployStepCreateNamespace(api *function.API, region scw.Region, functionNam
 		})
 
 		namespace, err = api.WaitForNamespace(&function.WaitForNamespaceRequest{
-			NamespaceID: namespace.ID,
-			Region:      namespace.Region,
+			NamespaceID:   namespace.ID,
+			Region:        namespace.Region,
+			Timeout:       core.WaitTimeout(t.Ctx, nil),
+			RetryInterval: core.WaitRetryInterval(t.Ctx),
 		})
 		if err != nil {
 			return nil, fmt.Errorf("could not fetch created namespace: %w", err)
@@ -153,10 +157,12 @@ func DeployStepCreateNamespace(api *function.API, region scw.Region, functionNam
 }
 
 func DeployStepFetchNamespace(api *function.API, region scw.Region, namespaceID string) tasks.TaskFunc[any, *function.Namespace] {
-	return func(_ *tasks.Task, _ any) (nextArgs *function.Namespace, err error) {
+	return func(t *tasks.Task, _ any) (nextArgs *function.Namespace, err error) {
 		namespace, err := api.WaitForNamespace(&function.WaitForNamespaceRequest{
-			NamespaceID: namespaceID,
-			Region:      region,
+			NamespaceID:   namespaceID,
+			Region:        region,
+			Timeout:       core.WaitTimeout(t.Ctx, nil),
+			RetryInterval: core.WaitRetryInterval(t.Ctx),
 		})
 		if err != nil {
 			return nil, fmt.Errorf("could not fetch namespace: %w", err)
@@ -252,7 +258,7 @@ func DeployStepFunctionUpload(httpClient *http.Client, scwClient *scw.Client, ap
 }
 
 func DeployStepFunctionDeploy(api *function.API, runtime function.FunctionRuntime) tasks.TaskFunc[*function.Function, *function.Function] {
-	return func(_ *tasks.Task, fc *function.Function) (*function.Function, error) {
+	return func(t *tasks.Task, fc *function.Function) (*function.Function, error) {
 		fc, err := api.UpdateFunction(&function.UpdateFunctionRequest{
 			Region:     fc.Region,
 			FunctionID: fc.ID,
@@ -265,7 +271,8 @@ func DeployStepFunctionDeploy(api *function.API, runtime function.FunctionRuntim
 		return api.WaitForFunction(&function.WaitForFunctionRequest{
 			FunctionID:    fc.ID,
 			Region:        fc.Region,
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(t.Ctx, nil),
+			RetryInterval: core.WaitRetryInterval(t.Ctx),
 		})
 	}
 }
@@ -67,6 +67,23 @@ Standard YAML output
 	default_zone: fr-par-1
 	send_telemetry: true
 
+JSON and YAML with field selection
+
+You can select the fields that you want to print, nested fields are separated by a dot. Missing fields are printed as null.
+The pretty option can be combined with a list of fields, unknown fields are rejected.
+
+	scw instance server list -o json=pretty,id,name,public_ip.address
+
+	[
+	  {
+	    "id": "088b01da-9ba7-40d2-bc55-eb3170f42185",
+	    "name": "scw-cool-franklin",
+	    "public_ip": {
+	      "address": "51.15.251.251"
+	    }
+	  }
+	]
+
 
 Template output
 
@@ -77,5 +94,85 @@ Visit https://golang.org/pkg/text/template/ to learn more about Go template form
 
 	foo||11111111-1111-1111-1111-111111111111
 	bar||22222222-2222-2222-2222-222222222222
+
+The following functions can be used in templates:
+toJson and toYaml encode a value, join concatenates a list with a separator, default replaces empty values, humanizeBytes formats a size, since prints the time elapsed since a date, upper and lower change the case of a string, indent indents each line of a string and env reads an environment variable.
+
+	scw instance server list -o template='{{ upper .Name }} {{ join "," .Tags }} {{ since .CreationDate }}'
+
+	FOO prod,web 3 days
+	BAR dev 2 hours
+
+A template can be read from a file by prefixing its path with @:
+
+	scw instance server list -o template=@servers.tpl
+
+CSV and TSV output
+
+Lists are printed with one row per item and other resources as a single row.
+Columns can be selected like in human output, nested fields are separated by a dot. Without selection, the columns of the human output are used when the command defines them, otherwise all fields are printed.
+
+	scw instance server list -o csv=ID,Name,PublicIP.Address
+
+	ID,Name,PublicIP.Address
+	088b01da-9ba7-40d2-bc55-eb3170f42185,scw-cool-franklin,51.15.251.251
+
+	scw instance server list -o tsv=ID,Name
+
+	ID	Name
+	088b01da-9ba7-40d2-bc55-eb3170f42185	scw-cool-franklin
+
+Markdown and HTML output
+
+Lists are printed as tables and other resources as a field/value table, ready to be pasted in documents.
+Columns and labels are the same as in human output and can be selected the same way. Sections become sub-tables under a heading.
+
+	scw instance server list -o markdown=ID,Name
+
+	| ID | NAME |
+	| --- | --- |
+	| 088b01da-9ba7-40d2-bc55-eb3170f42185 | scw-cool-franklin |
+
+	scw lb lb get 2d8c4a3e-13c1-4a3b-9f56-0b0d4f8e1a7c -o html > lb.html
+
+NDJSON output
+
+Lists are printed with one JSON object per line and other resources on a single line.
+The items of a list are printed as returned by the API as soon as each page is fetched instead of waiting for the whole list.
+Other outputs, e.g. human, and list commands with custom behavior print the list once it is fetched.
+Lists are not streamed when --query, --filter or --sort-by is used as they need the whole result, nor when zone=all or region=all is used.
+
+	scw registry namespace list -o ndjson
+
+	{"id":"9a2ba8a4-9c84-4c36-a5f0-5b6d6e6e3c1a","name":"production","status":"ready"}
+	{"id":"5c1b1e2f-7d1a-4a53-8a3b-1f4c2e1a7b9d","name":"staging","status":"ready"}
+
+Query
+
+The --query flag takes a JMESPath expression (https://jmespath.org) that is applied on the result before it is printed, whatever the output format.
+Fields are accessed using their JSON names as shown with -o json.
+All JMESPath expressions and functions are supported, e.g. max_by(@, &creation_date).name.
+
+	scw instance server list -o json --query "[?state=='running'].public_ip.address"
+
+	["51.15.251.251"]
+
+Filter and sort
+
+The --filter and --sort-by flags are applied on list results before they are printed, whatever the output format.
+They can only be used with list commands, e.g. scw instance server list, other commands are rejected before they run.
+Fields are given using their JSON names as shown with -o json, nested fields are separated with a dot (public_ip.address).
+
+--filter takes a comma-separated list of conditions that must all match:
+  - key=value   the field is equal to value
+  - key!=value  the field is not equal to value
+  - key~value   the field contains value, ignoring case
+  - key!~value  the field does not contain value, ignoring case
+A list field (e.g. tags) matches if one of its elements matches.
+
+--sort-by takes a comma-separated list of fields. Prefix a field with - to sort in descending order.
+Numbers and dates are compared by value and items without value are listed last.
+
+	scw instance server list --filter state=running,tags~prod --sort-by -creation_date
 `
 )
@@ -0,0 +1,43 @@
+package help
+
+const (
+	shortPlugins = "Get help about how to extend the CLI with plugins"
+	longPlugins  = `Plugins
+
+A plugin is an executable named scw-<namespace> found in one of the directories of PATH.
+It adds the <namespace> namespace to the CLI, e.g. scw-ourteam is run by "scw ourteam ...".
+Plugins cannot replace the namespaces of the CLI and the first executable found in PATH is used.
+
+- Arguments and output
+
+  The plugin is run with the arguments following the namespace, including flags, and handles its own usage.
+  It uses the standard input and outputs of the CLI and its exit code is the exit code of the CLI.
+  Plugins are not run with --dry-run as the CLI cannot intercept their requests.
+
+- Environment variables
+
+  The plugin receives the settings resolved by the CLI in the environment variables read by the Scaleway SDKs:
+
+	SCW_CONFIG_PATH, SCW_PROFILE
+	SCW_ACCESS_KEY, SCW_SECRET_KEY
+	SCW_DEFAULT_ORGANIZATION_ID, SCW_DEFAULT_PROJECT_ID
+	SCW_DEFAULT_REGION, SCW_DEFAULT_ZONE
+
+- Manifest
+
+  Plugins are listed in the usage of the CLI and autocompleted.
+  To describe its commands, a plugin prints a JSON manifest when it is run with --scw-plugin-manifest:
+
+	{
+	  "short": "Manage our team resources",
+	  "long": "Longer description shown in the usage",
+	  "commands": [
+	    {"path": "server list", "short": "List servers", "args": ["name", "zone"]}
+	  ]
+	}
+
+  Command paths have a resource and a verb at most.
+  Manifests are only read to print the usage of the CLI or to autocomplete plugin commands.
+  They are cached until the plugin executable is modified.
+`
+)
@@ -0,0 +1,192 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+)
+
+func GetCommands() *core.Commands {
+	cmds := core.NewCommands(
+		historyRoot(),
+		historyListCommand(),
+		historyShowCommand(),
+		historyReplayCommand(),
+	)
+
+	// Browsing the history does not add to it.
+	for _, cmd := range cmds.GetAll() {
+		cmd.DisableHistory = true
+	}
+
+	return cmds
+}
+
+func historyRoot() *core.Command {
+	return &core.Command{
+		Groups: []string{"utility"},
+		Short:  "Browse the commands run with the CLI",
+		Long: `The history records each command run with the CLI in the history.jsonl file of the cache directory.
+
+An entry contains the date, the command and its args, the profile, the exit code and the IDs of the returned resources.
+The values of secret args, e.g. password or secret-key, are replaced by <redacted>.`,
+		Namespace: "history",
+	}
+}
+
+type historyListRequest struct {
+	Limit int
+}
+
+func historyListCommand() *core.Command {
+	return &core.Command{
+		Short:                "List the commands of the history",
+		Long:                 `List the most recent commands of the history, oldest first.`,
+		Namespace:            "history",
+		Resource:             "list",
+		AllowAnonymousClient: true,
+		ArgsType:             reflect.TypeOf(historyListRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:    "limit",
+				Short:   "Number of commands to list, 0 lists the whole history",
+				Default: core.DefaultValueSetter("20"),
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "List the last 20 commands",
+				Raw:   "scw history list",
+			},
+			{
+				Short: "Find the commands that deleted resources",
+				Raw:   "scw history list limit=0 --filter command~delete",
+			},
+		},
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Number", FieldName: "Number"},
+				{Label: "Time", FieldName: "Time"},
+				{Label: "Profile", FieldName: "Profile"},
+				{Label: "Command", FieldName: "Command"},
+				{Label: "Exit Code", FieldName: "ExitCode"},
+				{Label: "Resource IDs", FieldName: "ResourceIDs"},
+			},
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			request := argsI.(*historyListRequest)
+			if request.Limit < 0 {
+				return nil, &core.CliError{
+					Err:  fmt.Errorf("invalid limit %d", request.Limit),
+					Hint: "limit must be positive, 0 lists the whole history",
+				}
+			}
+
+			entries, err := core.ReadHistory(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if request.Limit > 0 && len(entries) > request.Limit {
+				entries = entries[len(entries)-request.Limit:]
+			}
+
+			return entries, nil
+		},
+	}
+}
+
+type historyEntryRequest struct {
+	Number int
+}
+
+var historyNumberArgSpec = &core.ArgSpec{
+	Name:       "number",
+	Short:      "Number of the command, as listed by scw history list",
+	Required:   true,
+	Positional: true,
+}
+
+func historyShowCommand() *core.Command {
+	return &core.Command{
+		Short:                "Show a command of the history",
+		Namespace:            "history",
+		Resource:             "show",
+		AllowAnonymousClient: true,
+		ArgsType:             reflect.TypeOf(historyEntryRequest{}),
+		ArgSpecs:             core.ArgSpecs{historyNumberArgSpec},
+		Examples: []*core.Example{
+			{
+				Short: "Show the args and the returned resources of the command number 42",
+				Raw:   "scw history show 42",
+			},
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			return getEntry(ctx, argsI.(*historyEntryRequest).Number)
+		},
+	}
+}
+
+func historyReplayCommand() *core.Command {
+	return &core.Command{
+		Short: "Run a command of the history again",
+		Long: `Run a command of the history again, with the same args and profile.
+
+Commands with redacted args cannot be replayed as the values of their secret args were not recorded.`,
+		Namespace:            "history",
+		Resource:             "replay",
+		AllowAnonymousClient: true,
+		ArgsType:             reflect.TypeOf(historyEntryRequest{}),
+		ArgSpecs:             core.ArgSpecs{historyNumberArgSpec},
+		Examples: []*core.Example{
+			{
+				Short: "Run the command number 42 again",
+				Raw:   "scw history replay 42",
+			},
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			entry, err := getEntry(ctx, argsI.(*historyEntryRequest).Number)
+			if err != nil {
+				return nil, err
+			}
+			if entry.Redacted {
+				return nil, &core.CliError{
+					Err:  fmt.Errorf("command %d has redacted args", entry.Number),
+					Hint: fmt.Sprintf("Run the command again with the values of its secret args, see 'scw history show %d'", entry.Number),
+				}
+			}
+
+			config := core.BootstrapConfigFromContext(ctx, entry.Args)
+			// The last --profile flag wins, the command runs with the profile it was recorded with.
+			if entry.Profile != "" && entry.Profile != core.ExtractProfileName(ctx) {
+				config.Args = append(config.Args, "--profile", entry.Profile)
+			}
+
+			// The command prints its own result and errors.
+			exitCode, _, err := core.Bootstrap(config)
+			if err != nil {
+				return nil, &core.CliError{Empty: true, Code: exitCode}
+			}
+			return &core.SuccessResult{Empty: true}, nil
+		},
+	}
+}
+
+func getEntry(ctx context.Context, number int) (*core.HistoryEntry, error) {
+	entries, err := core.ReadHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Numbers do not change when the oldest commands are dropped from the history.
+	for _, entry := range entries {
+		if entry.Number == number {
+			return entry, nil
+		}
+	}
+	return nil, &core.CliError{
+		Err:  errors.New("no command with this number in the history"),
+		Hint: "Run 'scw history list' to get the number of a command, the oldest commands are dropped from the history",
+	}
+}
@@ -0,0 +1,244 @@
+package history_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/history"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type flower struct {
+	ID   string
+	Name string
+}
+
+type flowerEnvironmentVariable struct {
+	Key   string
+	Value string
+}
+
+type flowerCreateRequest struct {
+	Name                       string
+	Password                   string
+	SecretID                   string
+	SecretEnvironmentVariables []*flowerEnvironmentVariable
+}
+
+type flowerUnlockRequest struct {
+	Code string
+}
+
+func testCommands() *core.Commands {
+	commands := history.GetCommands()
+	commands.Merge(core.NewCommands(
+		&core.Command{
+			Namespace: "test",
+			Resource:  "flower",
+			Verb:      "create",
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name: "name",
+				},
+				{
+					Name: "password",
+				},
+				{
+					Name: "secret-id",
+				},
+				{
+					Name: "secret-environment-variables.{index}.key",
+				},
+				{
+					Name: "secret-environment-variables.{index}.value",
+				},
+			},
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(flowerCreateRequest{}),
+			Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+				name := argsI.(*flowerCreateRequest).Name
+				return &flower{ID: "flower-" + name, Name: name}, nil
+			},
+		},
+		&core.Command{
+			Namespace: "test",
+			Resource:  "flower",
+			Verb:      "unlock",
+			ArgSpecs: core.ArgSpecs{
+				{
+					Name:       "code",
+					Positional: true,
+					Secret:     true,
+				},
+			},
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(flowerUnlockRequest{}),
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return &core.SuccessResult{Message: "unlocked"}, nil
+			},
+		},
+	))
+	return commands
+}
+
+// testHistory is written in the cache directory before running the tested command.
+const testHistory = `{"time":"2024-03-01T10:00:00Z","command":"test flower create","args":["test","flower","create","name=rose"],"profile":"default","exit_code":0,"resource_ids":["flower-rose"]}
+not a history entry
+{"time":"2024-03-01T10:05:00Z","command":"test flower create","args":["test","flower","create","name=tulip","password=<redacted>"],"redacted":true,"profile":"default","exit_code":0,"resource_ids":["flower-tulip"]}
+`
+
+// testTrimmedHistory is a history whose oldest entries were dropped.
+const testTrimmedHistory = `{"number":41,"time":"2024-03-01T10:00:00Z","command":"test flower create","args":["test","flower","create","name=rose"],"profile":"default","exit_code":0,"resource_ids":["flower-rose"]}
+{"number":42,"time":"2024-03-01T10:05:00Z","command":"test flower create","args":["test","flower","create","name=daisy"],"profile":"default","exit_code":0,"resource_ids":["flower-daisy"]}
+`
+
+func writeHistory(content string) core.BeforeFunc {
+	return func(ctx *core.BeforeFuncCtx) error {
+		return os.WriteFile(filepath.Join(ctx.OverrideEnv[scw.ScwCacheDirEnv], "history.jsonl"), []byte(content), 0o600)
+	}
+}
+
+func Test_History(t *testing.T) {
+	t.Run("record", core.Test(&core.TestConfig{
+		Commands:   testCommands(),
+		TmpHomeDir: true,
+		Cmd:        "scw test flower create name=daisy password=hunter2 secret-id=11111111-1111-1111-1111-111111111111",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			func(t *testing.T, ctx *core.CheckFuncCtx) {
+				t.Helper()
+				content, err := os.ReadFile(filepath.Join(ctx.OverrideEnv[scw.ScwCacheDirEnv], "history.jsonl"))
+				require.NoError(t, err)
+				assert.Contains(t, string(content), `{"number":1,`)
+				assert.Contains(t, string(content), `"command":"test flower create","args":["test","flower","create","name=daisy","password=<redacted>","secret-id=11111111-1111-1111-1111-111111111111"],"redacted":true`)
+				assert.Contains(t, string(content), `"exit_code":0,"resource_ids":["flower-daisy"]`)
+				assert.NotContains(t, string(content), "hunter2")
+			},
+		),
+	}))
+
+	t.Run("record secret list", core.Test(&core.TestConfig{
+		Commands:   testCommands(),
+		TmpHomeDir: true,
+		Cmd:        "scw test flower create name=daisy secret-environment-variables.0.key=TOKEN secret-environment-variables.0.value=hunter2",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			func(t *testing.T, ctx *core.CheckFuncCtx) {
+				t.Helper()
+				content, err := os.ReadFile(filepath.Join(ctx.OverrideEnv[scw.ScwCacheDirEnv], "history.jsonl"))
+				require.NoError(t, err)
+				assert.Contains(t, string(content), `"secret-environment-variables.0.value=<redacted>"`)
+				assert.NotContains(t, string(content), "hunter2")
+			},
+		),
+	}))
+
+	t.Run("record positional secret", core.Test(&core.TestConfig{
+		Commands:   testCommands(),
+		TmpHomeDir: true,
+		Cmd:        "scw test flower unlock hunter2",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			func(t *testing.T, ctx *core.CheckFuncCtx) {
+				t.Helper()
+				content, err := os.ReadFile(filepath.Join(ctx.OverrideEnv[scw.ScwCacheDirEnv], "history.jsonl"))
+				require.NoError(t, err)
+				assert.Contains(t, string(content), `"args":["test","flower","unlock","<redacted>"],"redacted":true`)
+				assert.NotContains(t, string(content), "hunter2")
+			},
+		),
+	}))
+
+	t.Run("list", core.Test(&core.TestConfig{
+		Commands:   testCommands(),
+		TmpHomeDir: true,
+		BeforeFunc: writeHistory(testHistory),
+		Cmd:        "scw history list -o json",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("replay", core.Test(&core.TestConfig{
+		Commands:   testCommands(),
+		TmpHomeDir: true,
+		BeforeFunc: writeHistory(testHistory),
+		Cmd:        "scw history replay 1",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("replay redacted", core.Test(&core.TestConfig{
+		Commands:   testCommands(),
+		TmpHomeDir: true,
+		BeforeFunc: writeHistory(testHistory),
+		Cmd:        "scw history replay 2",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	t.Run("unknown number", core.Test(&core.TestConfig{
+		Commands:   testCommands(),
+		TmpHomeDir: true,
+		BeforeFunc: writeHistory(testHistory),
+		Cmd:        "scw history show 3",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	t.Run("replay trimmed", core.Test(&core.TestConfig{
+		Commands:   testCommands(),
+		TmpHomeDir: true,
+		BeforeFunc: writeHistory(testTrimmedHistory),
+		Cmd:        "scw history replay 42",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
+
+	t.Run("dropped number", core.Test(&core.TestConfig{
+		Commands:   testCommands(),
+		TmpHomeDir: true,
+		BeforeFunc: writeHistory(testTrimmedHistory),
+		Cmd:        "scw history show 2",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(1),
+		),
+	}))
+
+	// Entries recorded without number keep the one of their position when the history is trimmed.
+	t.Run("record after trim", core.Test(&core.TestConfig{
+		Commands:   testCommands(),
+		TmpHomeDir: true,
+		BeforeFunc: writeHistory(strings.Repeat(strings.SplitAfter(testHistory, "\n")[0], 30000)),
+		Cmd:        "scw test flower create name=daisy",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			func(t *testing.T, ctx *core.CheckFuncCtx) {
+				t.Helper()
+				content, err := os.ReadFile(filepath.Join(ctx.OverrideEnv[scw.ScwCacheDirEnv], "history.jsonl"))
+				require.NoError(t, err)
+				lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+				assert.Len(t, lines, 15001)
+				assert.True(t, strings.HasPrefix(lines[0], `{"number":15001,`), lines[0])
+				assert.True(t, strings.HasPrefix(lines[len(lines)-1], `{"number":30001,`), lines[len(lines)-1])
+			},
+		),
+	}))
+}
@@ -71,8 +71,8 @@ func deploymentCreateBuilder(c *core.Command) *core.Command {
 			DeploymentID:  respI.(*inference.Deployment).ID,
 			Region:        respI.(*inference.Deployment).Region,
 			Status:        respI.(*inference.Deployment).Status,
-			Timeout:       scw.TimeDurationPtr(deploymentActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(deploymentActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 	c.Interceptor = func(ctx context.Context, argsI interface{}, runner core.CommandRunner) (interface{}, error) {
@@ -120,8 +120,8 @@ func deploymentDeleteBuilder(c *core.Command) *core.Command {
 			DeploymentID:  respI.(*inference.Deployment).ID,
 			Region:        respI.(*inference.Deployment).Region,
 			Status:        respI.(*inference.Deployment).Status,
-			Timeout:       scw.TimeDurationPtr(deploymentActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(deploymentActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			notFoundError := &scw.ResourceNotFoundError{}
@@ -7,12 +7,14 @@ import (
 
 	"github.com/scaleway/scaleway-cli/v2/core"
 	"github.com/scaleway/scaleway-cli/v2/core/human"
+	"github.com/scaleway/scaleway-cli/v2/internal/profiles"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 const (
 	defaultOrigin        = "default"
 	defaultProfileOrigin = "default profile"
+	projectConfigOrigin  = "project config file"
 	unknownOrigin        = "unknown"
 )
 
@@ -70,25 +72,75 @@ func infosRoot() *core.Command {
 		},
 		Run: func(ctx context.Context, argsI interface{}) (i interface{}, e error) {
 			req := argsI.(*infoArgs)
-			config, _ := scw.LoadConfigFromPath(core.ExtractConfigPath(ctx))
-			profileName := core.ExtractProfileName(ctx)
+			config, extensions, _ := profiles.LoadConfig(core.ExtractConfigPath(ctx))
+			resolved := resolveProfile(config, extensions, core.ExtractProfileName(ctx))
+			settings := []*setting{
+				configPath(ctx),
+			}
+			if projectConfig := core.ExtractProjectConfig(ctx); projectConfig != nil {
+				settings = append(settings, &setting{
+					Key:    "project_config_path",
+					Value:  projectConfig.Path,
+					Origin: "working directory",
+				})
+			}
+			settings = append(settings,
+				profile(ctx, config),
+				defaultRegion(ctx, resolved),
+				defaultZone(ctx, resolved),
+				defaultOrganizationID(ctx, resolved),
+				defaultProjectID(ctx, resolved),
+				accessKey(ctx, resolved),
+				secretKey(ctx, resolved, req.ShowSecret),
+			)
+			if command := secretKeyCommand(ctx, resolved); command != nil {
+				settings = append(settings, command)
+			}
 			return &infoResult{
 				BuildInfo: core.ExtractBuildInfo(ctx),
-				Settings: []*setting{
-					configPath(ctx),
-					profile(ctx, config),
-					defaultRegion(ctx, config, profileName),
-					defaultZone(ctx, config, profileName),
-					defaultOrganizationID(ctx, config, profileName),
-					defaultProjectID(ctx, config, profileName),
-					accessKey(ctx, config, profileName),
-					secretKey(ctx, config, profileName, req.ShowSecret),
-				},
+				Settings:  settings,
 			}, nil
 		},
 	}
 }
 
+// resolvedProfile is the profile of the config file with the values it inherits, see profiles.Resolve.
+type resolvedProfile struct {
+	*scw.Profile
+	origins profiles.Origins
+	// secretKeyCommand is the extension setting the secret_key_command of the profile, if any.
+	secretKeyCommand *profiles.Extension
+}
+
+// resolveProfile returns nil if there is no config file.
+// Unknown profiles and profiles with an invalid extends key fall back to the default profile.
+func resolveProfile(config *scw.Config, extensions *profiles.Extensions, profileName string) *resolvedProfile {
+	if config == nil {
+		return nil
+	}
+	profile, origins, err := profiles.Resolve(config, extensions, profileName)
+	if err != nil {
+		profileName = scw.DefaultProfileName
+		profile, origins, _ = profiles.Resolve(config, extensions, profileName)
+	}
+	resolved := &resolvedProfile{Profile: profile, origins: origins}
+
+	extension, origin, _ := profiles.SecretKeyCommand(config, extensions, profileName)
+	if extension != nil {
+		resolved.secretKeyCommand = extension
+		resolved.origins["secret_key_command"] = origin
+	}
+	return resolved
+}
+
+// origin returns the origin of a value, i.e. the profile it comes from.
+func (p *resolvedProfile) origin(key string) string {
+	if p.origins[key] == scw.DefaultProfileName {
+		return defaultProfileOrigin
+	}
+	return fmt.Sprintf("profile (%s)", p.origins[key])
+}
+
 func configPath(ctx context.Context) *setting {
 	setting := &setting{
 		Key:   "config_path",
@@ -120,6 +172,9 @@ func profile(ctx context.Context, config *scw.Config) *setting {
 	case core.ExtractEnv(ctx, scw.ScwActiveProfileEnv) != "":
 		setting.Origin = fmt.Sprintf("env (%s)", scw.ScwActiveProfileEnv)
 		setting.Value = core.ExtractEnv(ctx, scw.ScwActiveProfileEnv)
+	case core.ExtractProjectConfig(ctx) != nil && core.ExtractProjectConfig(ctx).Profile != "":
+		setting.Origin = projectConfigOrigin
+		setting.Value = core.ExtractProjectConfig(ctx).Profile
 	case config != nil && config.ActiveProfile != nil:
 		setting.Origin = "active_profile in config file"
 		setting.Value = *config.ActiveProfile
@@ -129,31 +184,31 @@ func profile(ctx context.Context, config *scw.Config) *setting {
 	return setting
 }
 
-func defaultRegion(ctx context.Context, config *scw.Config, profileName string) *setting {
+func defaultRegion(ctx context.Context, profile *resolvedProfile) *setting {
 	setting := &setting{Key: "default_region"}
 	switch {
 	// Environment variable check
 	case core.ExtractEnv(ctx, scw.ScwDefaultRegionEnv) != "":
 		setting.Origin = fmt.Sprintf("env (%s)", scw.ScwDefaultRegionEnv)
 		setting.Value = core.ExtractEnv(ctx, scw.ScwDefaultRegionEnv)
+	// Project config file found from the working directory
+	case core.ExtractProjectConfig(ctx).ScwProfile().DefaultRegion != nil:
+		setting.Value = *core.ExtractProjectConfig(ctx).ScwProfile().DefaultRegion
+		setting.Origin = projectConfigOrigin
 	// There is no config file
-	case config == nil:
+	case profile == nil:
 		setting.Origin = defaultOrigin
-	// Config file with profile name
-	case config.Profiles[profileName] != nil && config.Profiles[profileName].DefaultRegion != nil:
-		setting.Origin = fmt.Sprintf("profile (%s)", profileName)
-		setting.Value = *config.Profiles[profileName].DefaultRegion
-	// Default config
-	case config.Profile.DefaultRegion != nil:
-		setting.Value = *config.Profile.DefaultRegion
-		setting.Origin = defaultProfileOrigin
+	// Config file profile or the profiles it extends
+	case profile.DefaultRegion != nil:
+		setting.Value = *profile.DefaultRegion
+		setting.Origin = profile.origin("default_region")
 	default:
 		setting.Origin = defaultOrigin
 	}
 	return setting
 }
 
-func defaultZone(ctx context.Context, config *scw.Config, profileName string) *setting {
+func defaultZone(ctx context.Context, profile *resolvedProfile) *setting {
 	setting := &setting{Key: "default_zone"}
 	client := core.ExtractClient(ctx)
 	defaultZone, exists := client.GetDefaultZone()
@@ -165,24 +220,24 @@ func defaultZone(ctx context.Context, config *scw.Config, profileName string) *s
 	case core.ExtractEnv(ctx, scw.ScwDefaultZoneEnv) != "":
 		setting.Origin = fmt.Sprintf("env (%s)", scw.ScwDefaultZoneEnv)
 		setting.Value = core.ExtractEnv(ctx, scw.ScwDefaultZoneEnv)
+	// Project config file found from the working directory
+	case core.ExtractProjectConfig(ctx).ScwProfile().DefaultZone != nil:
+		setting.Value = *core.ExtractProjectConfig(ctx).ScwProfile().DefaultZone
+		setting.Origin = projectConfigOrigin
 	// There is no config file
-	case config == nil:
+	case profile == nil:
 		setting.Origin = ""
-	// Config file with profile name
-	case config.Profiles[profileName] != nil && config.Profiles[profileName].DefaultZone != nil:
-		setting.Value = *config.Profiles[profileName].DefaultZone
-		setting.Origin = fmt.Sprintf("profile (%s)", profileName)
-	// Default config
-	case config.Profile.DefaultZone != nil:
-		setting.Value = *config.Profile.DefaultZone
-		setting.Origin = defaultProfileOrigin
+	// Config file profile or the profiles it extends
+	case profile.DefaultZone != nil:
+		setting.Value = *profile.DefaultZone
+		setting.Origin = profile.origin("default_zone")
 	default:
 		setting.Origin = defaultOrigin
 	}
 	return setting
 }
 
-func defaultOrganizationID(ctx context.Context, config *scw.Config, profileName string) *setting {
+func defaultOrganizationID(ctx context.Context, profile *resolvedProfile) *setting {
 	setting := &setting{Key: "default_organization_id"}
 	switch {
 	// Environment variable check
@@ -190,47 +245,43 @@ func defaultOrganizationID(ctx context.Context, config *scw.Config, profileName
 		setting.Value = core.ExtractEnv(ctx, scw.ScwDefaultOrganizationIDEnv)
 		setting.Origin = fmt.Sprintf("env (%s)", scw.ScwDefaultOrganizationIDEnv)
 	// There is no config file
-	case config == nil:
+	case profile == nil:
 		setting.Origin = ""
-	// Config file with profile name
-	case config.Profiles[profileName] != nil && config.Profiles[profileName].DefaultOrganizationID != nil:
-		setting.Value = *config.Profiles[profileName].DefaultOrganizationID
-		setting.Origin = fmt.Sprintf("profile (%s)", profileName)
-	// Default config
-	case config.Profile.DefaultOrganizationID != nil:
-		setting.Value = *config.Profile.DefaultOrganizationID
-		setting.Origin = defaultProfileOrigin
+	// Config file profile or the profiles it extends
+	case profile.DefaultOrganizationID != nil:
+		setting.Value = *profile.DefaultOrganizationID
+		setting.Origin = profile.origin("default_organization_id")
 	default:
 		setting.Origin = unknownOrigin
 	}
 	return setting
 }
 
-func defaultProjectID(ctx context.Context, config *scw.Config, profileName string) *setting {
+func defaultProjectID(ctx context.Context, profile *resolvedProfile) *setting {
 	setting := &setting{Key: "default_project_id"}
 	switch {
 	// Environment variable check
 	case core.ExtractEnv(ctx, scw.ScwDefaultProjectIDEnv) != "":
 		setting.Value = core.ExtractEnv(ctx, scw.ScwDefaultProjectIDEnv)
 		setting.Origin = fmt.Sprintf("env (%s)", scw.ScwDefaultProjectIDEnv)
+	// Project config file found from the working directory
+	case core.ExtractProjectConfig(ctx).ScwProfile().DefaultProjectID != nil:
+		setting.Value = *core.ExtractProjectConfig(ctx).ScwProfile().DefaultProjectID
+		setting.Origin = projectConfigOrigin
 	// There is no config file
-	case config == nil:
+	case profile == nil:
 		setting.Origin = ""
-	// Config file with profile name
-	case config.Profiles[profileName] != nil && config.Profiles[profileName].DefaultProjectID != nil:
-		setting.Value = *config.Profiles[profileName].DefaultProjectID
-		setting.Origin = fmt.Sprintf("profile (%s)", profileName)
-	// Default config
-	case config.Profile.DefaultProjectID != nil:
-		setting.Value = *config.Profile.DefaultProjectID
-		setting.Origin = defaultProfileOrigin
+	// Config file profile or the profiles it extends
+	case profile.DefaultProjectID != nil:
+		setting.Value = *profile.DefaultProjectID
+		setting.Origin = profile.origin("default_project_id")
 	default:
 		setting.Origin = unknownOrigin
 	}
 	return setting
 }
 
-func accessKey(ctx context.Context, config *scw.Config, profileName string) *setting {
+func accessKey(ctx context.Context, profile *resolvedProfile) *setting {
 	setting := &setting{Key: "access_key"}
 	switch {
 	// Environment variable check
@@ -238,16 +289,12 @@ func accessKey(ctx context.Context, config *scw.Config, profileName string) *set
 		setting.Value = core.ExtractEnv(ctx, scw.ScwAccessKeyEnv)
 		setting.Origin = fmt.Sprintf("env (%s)", scw.ScwAccessKeyEnv)
 	// There is no config file
-	case config == nil:
+	case profile == nil:
 		setting.Origin = ""
-	// Config file with profile name
-	case config.Profiles[profileName] != nil && config.Profiles[profileName].AccessKey != nil:
-		setting.Value = *config.Profiles[profileName].AccessKey
-		setting.Origin = fmt.Sprintf("profile (%s)", profileName)
-	// Default config
-	case config.Profile.AccessKey != nil:
-		setting.Value = *config.Profile.AccessKey
-		setting.Origin = defaultProfileOrigin
+	// Config file profile or the profiles it extends
+	case profile.AccessKey != nil:
+		setting.Value = *profile.AccessKey
+		setting.Origin = profile.origin("access_key")
 	default:
 		setting.Origin = unknownOrigin
 	}
@@ -265,7 +312,7 @@ func hideSecretKey(k string) string {
 	}
 }
 
-func secretKey(ctx context.Context, config *scw.Config, profileName string, showSecret bool) *setting {
+func secretKey(ctx context.Context, profile *resolvedProfile, showSecret bool) *setting {
 	setting := &setting{Key: "secret_key"}
 	switch {
 	// Environment variable check
@@ -273,16 +320,22 @@ func secretKey(ctx context.Context, config *scw.Config, profileName string, show
 		setting.Origin = fmt.Sprintf("env (%s)", scw.ScwSecretKeyEnv)
 		setting.Value = core.ExtractEnv(ctx, scw.ScwSecretKeyEnv)
 	// There is no config file
-	case config == nil:
+	case profile == nil:
 		setting.Origin = ""
-	// Config file with profile name
-	case config.Profiles[profileName] != nil && config.Profiles[profileName].SecretKey != nil:
-		setting.Value = *config.Profiles[profileName].SecretKey
-		setting.Origin = fmt.Sprintf("profile (%s)", profileName)
-	// Default config
-	case config.Profile.SecretKey != nil:
-		setting.Value = *config.Profile.SecretKey
-		setting.Origin = defaultProfileOrigin
+	// Config file profile or the profiles it extends
+	case profile.SecretKey != nil:
+		setting.Value = *profile.SecretKey
+		setting.Origin = profile.origin("secret_key")
+	// Output of a command, it is only run to reveal the secret
+	case profile.secretKeyCommand != nil:
+		setting.Origin = "secret_key_command"
+		if showSecret {
+			key, err := core.SecretKeyFromCommand(ctx, profile.origins["secret_key_command"], profile.secretKeyCommand)
+			if err != nil {
+				core.ExtractLogger(ctx).Warningf("%s\n", err)
+			}
+			setting.Value = key
+		}
 	default:
 		setting.Origin = unknownOrigin
 	}
@@ -291,3 +344,19 @@ func secretKey(ctx context.Context, config *scw.Config, profileName string, show
 	}
 	return setting
 }
+
+// secretKeyCommand returns nil if the secret key does not come from a secret_key_command.
+func secretKeyCommand(ctx context.Context, profile *resolvedProfile) *setting {
+	if core.ExtractEnv(ctx, scw.ScwSecretKeyEnv) != "" || profile == nil || profile.secretKeyCommand == nil {
+		return nil
+	}
+	command := profile.secretKeyCommand.SecretKeyCommand
+	if profile.secretKeyCommand.SecretKeyCache != "" {
+		command += fmt.Sprintf(" (cached for the %s)", profile.secretKeyCommand.SecretKeyCache)
+	}
+	return &setting{
+		Key:    "secret_key_command",
+		Value:  command,
+		Origin: profile.origin("secret_key_command"),
+	}
+}
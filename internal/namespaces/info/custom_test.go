@@ -1,12 +1,40 @@
 package info_test
 
 import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/scaleway/scaleway-cli/v2/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/info"
 )
 
+const extendedConfigPath = "/tmp/scw-info-extends/config.yaml"
+
+// extendedConfig has a prod profile inheriting the credentials of the base profile.
+// The vault profile only inherits the access key, its secret key is the output of a command.
+const extendedConfig = `access_key: SCWXXXXXXXXXXXXXXXXX
+secret_key: 11111111-1111-1111-1111-111111111111
+default_organization_id: 11111111-1111-1111-1111-111111111111
+default_region: fr-par
+default_zone: fr-par-1
+profiles:
+  prod:
+    extends: base
+    default_project_id: 33333333-3333-3333-3333-333333333333
+    default_zone: nl-ams-1
+  base:
+    access_key: SCWBASEXXXXXXXXXXXXX
+    secret_key: 22222222-2222-2222-2222-222222222222
+  vault:
+    extends: base
+    secret_key_command: pass show scw/vault
+    secret_key_cache: process
+`
+
 func Test_Info(t *testing.T) {
 	t.Run("Simple", core.Test(&core.TestConfig{
 		Commands: info.GetCommands(),
@@ -43,4 +71,76 @@ func Test_Info(t *testing.T) {
 			"SCW_DEFAULT_ZONE":            "fr-par-1",
 		},
 	}))
+
+	t.Run("Extends", core.Test(&core.TestConfig{
+		Commands: info.GetCommands(),
+		BeforeFunc: func(ctx *core.BeforeFuncCtx) error {
+			err := os.MkdirAll(filepath.Dir(extendedConfigPath), 0o700)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(extendedConfigPath, []byte(extendedConfig), 0o600)
+		},
+		Cmd: "scw -p prod -c " + extendedConfigPath + " info",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+		AfterFunc: func(_ *core.AfterFuncCtx) error {
+			return os.RemoveAll(filepath.Dir(extendedConfigPath))
+		},
+	}))
+
+	t.Run("Secret key command", core.Test(&core.TestConfig{
+		Commands: info.GetCommands(),
+		BeforeFunc: func(ctx *core.BeforeFuncCtx) error {
+			err := os.MkdirAll(filepath.Dir(extendedConfigPath), 0o700)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(extendedConfigPath, []byte(extendedConfig), 0o600)
+		},
+		OverrideExec: func(_ *core.ExecFuncCtx, cmd *exec.Cmd) (int, error) {
+			_, err := fmt.Fprintln(cmd.Stdout, "33333333-3333-3333-3333-333333333333")
+			return 0, err
+		},
+		Cmd: "scw -p vault -c " + extendedConfigPath + " info show-secret=true",
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+		AfterFunc: func(_ *core.AfterFuncCtx) error {
+			return os.RemoveAll(filepath.Dir(extendedConfigPath))
+		},
+	}))
+
+	// The project config file is in the working directory of the test, i.e. its home directory.
+	t.Run("Project config", core.Test(&core.TestConfig{
+		Commands:   info.GetCommands(),
+		TmpHomeDir: true,
+		BeforeFunc: func(ctx *core.BeforeFuncCtx) error {
+			home := ctx.OverrideEnv["HOME"]
+			err := os.MkdirAll(filepath.Join(home, ".config", "scw"), 0o700)
+			if err != nil {
+				return err
+			}
+			err = os.WriteFile(filepath.Join(home, ".config", "scw", "config.yaml"), []byte(extendedConfig), 0o600)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(filepath.Join(home, ".scw.yaml"), []byte(`profile: prod
+default_project_id: 44444444-4444-4444-4444-444444444444
+default_zone: nl-ams-2
+output: json
+`), 0o600)
+		},
+		Cmd: "scw info",
+		Check: core.TestCheckCombine(
+			core.TestCheckGoldenAndReplacePatterns(core.GoldenReplacement{
+				Pattern:     regexp.MustCompile(`/tmp/scw[0-9]+`),
+				Replacement: "/tmp/scw",
+			}),
+			core.TestCheckExitCode(0),
+		),
+	}))
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/autocomplete"
 	iamcommands "github.com/scaleway/scaleway-cli/v2/internal/namespaces/iam/v1alpha1"
+	"github.com/scaleway/scaleway-cli/v2/internal/profiles"
 	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
 	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -82,6 +83,7 @@ Default path for configuration file is based on the following priority order:
 - $HOME/.config/scw/config.yaml
 - $USERPROFILE/.config/scw/config.yaml`,
 		Namespace:            "init",
+		LocalEffects:         true,
 		AllowAnonymousClient: true,
 		ArgsType:             reflect.TypeOf(Args{}),
 		ArgSpecs: core.ArgSpecs{
@@ -234,7 +236,7 @@ Default path for configuration file is based on the following priority order:
 
 			// Persist configuration on disk
 			interactive.Printf("Config saved at %s:\n%s\n", configPath, terminal.Style(fmt.Sprint(config), color.Faint))
-			err = config.SaveTo(configPath)
+			err = profiles.SaveConfig(config, configPath)
 			if err != nil {
 				return nil, err
 			}
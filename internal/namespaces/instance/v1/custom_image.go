@@ -301,6 +301,8 @@ func imageDeleteBuilder(c *core.Command) *core.Command {
 						SnapshotID:     snapshot.ID,
 						Zone:           args.Zone,
 						TerminalStatus: &terminalStatus,
+						Timeout:        core.WaitTimeout(ctx, nil),
+						RetryInterval:  core.WaitRetryInterval(ctx),
 					})
 					if err != nil {
 						return nil, err
@@ -347,8 +349,8 @@ func imageWaitCommand() *core.Command {
 			return api.WaitForImage(&instance.WaitForImageRequest{
 				Zone:          argsI.(*instance.WaitForImageRequest).Zone,
 				ImageID:       argsI.(*instance.WaitForImageRequest).ImageID,
-				Timeout:       argsI.(*instance.WaitForImageRequest).Timeout,
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, argsI.(*instance.WaitForImageRequest).Timeout),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 		},
 		ArgSpecs: core.ArgSpecs{
@@ -675,8 +675,8 @@ func serverWaitCommand() *core.Command {
 			return instance.NewAPI(core.ExtractClient(ctx)).WaitForServer(&instance.WaitForServerRequest{
 				Zone:          args.Zone,
 				ServerID:      args.ServerID,
-				Timeout:       scw.TimeDurationPtr(args.Timeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(args.Timeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 		},
 		ArgSpecs: core.ArgSpecs{
@@ -206,8 +206,8 @@ Once your image is ready you will be able to create a new server based on this i
 			return api.WaitForImage(&instance.WaitForImageRequest{
 				ImageID:       resp.Image.ID,
 				Zone:          resp.Image.Zone,
-				Timeout:       scw.TimeDurationPtr(serverActionTimeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(serverActionTimeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 		},
 		ArgSpecs: core.ArgSpecs{
@@ -318,8 +318,8 @@ func serverTerminateCommand() *core.Command {
 			_, err := api.WaitForServer(&instance.WaitForServerRequest{
 				Zone:          server.Zone,
 				ServerID:      server.ID,
-				Timeout:       scw.TimeDurationPtr(serverActionTimeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(serverActionTimeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 			if err != nil {
 				err = errors.Unwrap(err)
@@ -334,8 +334,10 @@ func serverTerminateCommand() *core.Command {
 						continue
 					}
 					_, err := api.WaitForVolume(&instance.WaitForVolumeRequest{
-						VolumeID: volume.ID,
-						Zone:     volume.Zone,
+						VolumeID:      volume.ID,
+						Zone:          volume.Zone,
+						Timeout:       core.WaitTimeout(ctx, nil),
+						RetryInterval: core.WaitRetryInterval(ctx),
 					})
 					if err != nil {
 						if errors.As(err, &notFoundErr) {
@@ -422,6 +424,10 @@ func shouldDeleteBlockVolumes(ctx context.Context, server *instance.GetServerRes
 	case withBlockFalse:
 		return false, nil
 	case withBlockPrompt:
+		// The block volumes are kept when confirmations are skipped, e.g. with --yes or --dry-run.
+		if !core.CanConfirm(ctx) {
+			return false, nil
+		}
 		// Only prompt user if at least one block volume is attached to the instance
 		for _, volume := range server.Server.Volumes {
 			if volume.VolumeType != instance.VolumeServerVolumeTypeBSSD {
@@ -476,8 +482,8 @@ func waitForServerFunc() core.WaitFunc {
 		return instance.NewAPI(core.ExtractClient(ctx)).WaitForServer(&instance.WaitForServerRequest{
 			Zone:          argsI.(*instanceUniqueActionRequest).Zone,
 			ServerID:      argsI.(*instanceUniqueActionRequest).ServerID,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(serverActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 }
@@ -514,8 +520,8 @@ func serverActionCommand() *core.Command {
 			return instance.NewAPI(core.ExtractClient(ctx)).WaitForServer(&instance.WaitForServerRequest{
 				Zone:          argsI.(*instanceActionRequest).Zone,
 				ServerID:      argsI.(*instanceActionRequest).ServerID,
-				Timeout:       scw.TimeDurationPtr(serverActionTimeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(serverActionTimeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 		},
 		ArgSpecs: argSpecs,
@@ -190,8 +190,8 @@ func instanceWaitServerCreateRun() core.WaitFunc {
 		return instance.NewAPI(core.ExtractClient(ctx)).WaitForServer(&instance.WaitForServerRequest{
 			Zone:          argsI.(*instanceCreateServerRequest).Zone,
 			ServerID:      respI.(*instance.Server).ID,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(serverActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 }
@@ -485,6 +485,25 @@ func Test_CreateServer(t *testing.T) {
 			AfterFunc: deleteServerAfterFunc(),
 		}))
 	})
+
+	////
+	// Dry run
+	////
+	t.Run("Dry run", func(t *testing.T) {
+		t.Run("Simple", core.Test(&core.TestConfig{
+			Commands: instance.GetCommands(),
+			Cmd:      testServerCommand("image=ubuntu_jammy name=yo stopped=true --dry-run"),
+			Check: core.TestCheckCombine(
+				core.TestCheckGolden(),
+				func(t *testing.T, ctx *core.CheckFuncCtx) {
+					t.Helper()
+					assert.Equal(t, "00000000-0000-0000-0000-000000000000", ctx.Result.(*instanceSDK.Server).ID)
+					assert.Equal(t, "yo", ctx.Result.(*instanceSDK.Server).Name)
+				},
+				core.TestCheckExitCode(0),
+			),
+		}))
+	})
 }
 
 // None of the tests below should succeed to create an instance.
@@ -101,8 +101,8 @@ func serverDeleteCommand() *core.Command {
 			_, err := api.WaitForServer(&instance.WaitForServerRequest{
 				Zone:          server.Zone,
 				ServerID:      server.ID,
-				Timeout:       scw.TimeDurationPtr(serverActionTimeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(serverActionTimeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 			if err != nil {
 				err = errors.Unwrap(err)
@@ -130,8 +130,8 @@ func serverDeleteCommand() *core.Command {
 				finalStateServer, err := api.WaitForServer(&instance.WaitForServerRequest{
 					Zone:          deleteServerArgs.Zone,
 					ServerID:      deleteServerArgs.ServerID,
-					Timeout:       scw.TimeDurationPtr(serverActionTimeout),
-					RetryInterval: core.DefaultRetryInterval,
+					Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(serverActionTimeout)),
+					RetryInterval: core.WaitRetryInterval(ctx),
 				})
 				if err != nil {
 					return nil, err
@@ -142,8 +142,8 @@ func serverDeleteCommand() *core.Command {
 						Zone:          deleteServerArgs.Zone,
 						ServerID:      deleteServerArgs.ServerID,
 						Action:        instance.ServerActionPoweroff,
-						Timeout:       scw.TimeDurationPtr(serverActionTimeout),
-						RetryInterval: core.DefaultRetryInterval,
+						Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(serverActionTimeout)),
+						RetryInterval: core.WaitRetryInterval(ctx),
 					})
 					if err != nil {
 						return nil, err
@@ -190,7 +190,7 @@ func serverDeleteCommand() *core.Command {
 				case volume.VolumeType == instance.VolumeServerVolumeTypeScratch:
 					continue
 				}
-				err := serverDeleteVolume(volume, api, block.NewAPI(client))
+				err := serverDeleteVolume(ctx, volume, api, block.NewAPI(client))
 				if err != nil {
 					return nil, err
 				}
@@ -230,7 +230,7 @@ func errorDeletingResource(err error) error {
 	}
 }
 
-func serverDeleteVolume(volume *instance.VolumeServer, instanceAPI *instance.API, blockAPI *block.API) error {
+func serverDeleteVolume(ctx context.Context, volume *instance.VolumeServer, instanceAPI *instance.API, blockAPI *block.API) error {
 	var err error
 
 	if volume.VolumeType == instance.VolumeServerVolumeTypeSbsVolume {
@@ -239,6 +239,8 @@ func serverDeleteVolume(volume *instance.VolumeServer, instanceAPI *instance.API
 			Zone:                 volume.Zone,
 			VolumeID:             volume.ID,
 			VolumeTerminalStatus: &volumeAvailable,
+			Timeout:              core.WaitTimeout(ctx, nil),
+			RetryInterval:        core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			return errorDeletingResource(err)
@@ -250,8 +252,10 @@ func serverDeleteVolume(volume *instance.VolumeServer, instanceAPI *instance.API
 		})
 	} else {
 		_, err = instanceAPI.WaitForVolume(&instance.WaitForVolumeRequest{
-			VolumeID: volume.ID,
-			Zone:     volume.Zone,
+			VolumeID:      volume.ID,
+			Zone:          volume.Zone,
+			Timeout:       core.WaitTimeout(ctx, nil),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			return errorDeletingResource(err)
@@ -68,7 +68,8 @@ func instanceServerGetRdpPassword() *core.Command {
 			_, err := apiInstance.WaitForServerRDPPassword(&instance.WaitForServerRDPPasswordRequest{
 				Zone:          args.Zone,
 				ServerID:      args.ServerID,
-				RetryInterval: core.DefaultRetryInterval,
+				RetryInterval: core.WaitRetryInterval(ctx),
+				Timeout:       core.WaitTimeout(ctx, nil),
 			})
 			if err != nil {
 				return nil, err
@@ -23,12 +23,13 @@ type instanceSSHServerRequest struct {
 
 func serverSSHCommand() *core.Command {
 	return &core.Command{
-		Short:     `SSH into a server`,
-		Long:      `Connect to distant server via the SSH protocol.`,
-		Namespace: "instance",
-		Verb:      "ssh",
-		Resource:  "server",
-		ArgsType:  reflect.TypeOf(instanceSSHServerRequest{}),
+		Short:        `SSH into a server`,
+		Long:         `Connect to distant server via the SSH protocol.`,
+		Namespace:    "instance",
+		Verb:         "ssh",
+		LocalEffects: true,
+		Resource:     "server",
+		ArgsType:     reflect.TypeOf(instanceSSHServerRequest{}),
 		ArgSpecs: core.ArgSpecs{
 			{
 				Name:       "server-id",
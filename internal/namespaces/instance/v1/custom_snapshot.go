@@ -71,8 +71,8 @@ func snapshotCreateBuilder(c *core.Command) *core.Command {
 		return api.WaitForSnapshot(&instance.WaitForSnapshotRequest{
 			SnapshotID:    respI.(*instance.CreateSnapshotResponse).Snapshot.ID,
 			Zone:          argsI.(*customCreateSnapshotRequest).Zone,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(serverActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -121,8 +121,8 @@ func snapshotWaitCommand() *core.Command {
 			return api.WaitForSnapshot(&instance.WaitForSnapshotRequest{
 				Zone:          argsI.(*instance.WaitForSnapshotRequest).Zone,
 				SnapshotID:    argsI.(*instance.WaitForSnapshotRequest).SnapshotID,
-				Timeout:       argsI.(*instance.WaitForSnapshotRequest).Timeout,
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, argsI.(*instance.WaitForSnapshotRequest).Timeout),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 		},
 		ArgSpecs: core.ArgSpecs{
@@ -151,8 +151,8 @@ func snapshotUpdateBuilder(c *core.Command) *core.Command {
 		return api.WaitForSnapshot(&instance.WaitForSnapshotRequest{
 			SnapshotID:    snapshot.ID,
 			Zone:          snapshot.Zone,
-			Timeout:       scw.TimeDurationPtr(snapshotActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(snapshotActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -47,9 +47,10 @@ func sshConfigInstallCommand() *core.Command {
 	availableZones = append(availableZones, scw.Zone(core.AllLocalities))
 
 	return &core.Command{
-		Namespace: "instance",
-		Resource:  "ssh",
-		Verb:      "install-config",
+		Namespace:    "instance",
+		Resource:     "ssh",
+		Verb:         "install-config",
+		LocalEffects: true,
 		Short: `Install a ssh config with all your servers as host
 It generate hosts for instance servers, baremetal, apple-silicon and bastions`,
 		Long:     "Path of the config will be $HOME/.ssh/scaleway.config",
@@ -118,8 +118,8 @@ func volumeWaitCommand() *core.Command {
 			return instance.NewAPI(core.ExtractClient(ctx)).WaitForVolume(&instance.WaitForVolumeRequest{
 				Zone:          args.Zone,
 				VolumeID:      args.VolumeID,
-				Timeout:       scw.TimeDurationPtr(args.Timeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(args.Timeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 		},
 		ArgSpecs: core.ArgSpecs{
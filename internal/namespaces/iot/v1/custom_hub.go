@@ -29,8 +29,8 @@ func hubCreateBuilder(c *core.Command) *core.Command {
 		return api.WaitForHub(&iot.WaitForHubRequest{
 			HubID:         respI.(*iot.Hub).ID,
 			Region:        respI.(*iot.Hub).Region,
-			Timeout:       scw.TimeDurationPtr(hubActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(hubActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 	return c
@@ -16,3 +16,18 @@ func Test_CreateNetwork(t *testing.T) {
 		AfterFunc:  deleteHub(),
 	}))
 }
+
+// List commands print each page as soon as it is fetched with ndjson output, human output waits for the whole list.
+func Test_ListNetwork(t *testing.T) {
+	t.Run("Streamed ndjson", core.Test(&core.TestConfig{
+		Commands: iot.GetCommands(),
+		Cmd:      "scw iot network list -o ndjson",
+		Check:    core.TestCheckGolden(),
+	}))
+
+	t.Run("Human", core.Test(&core.TestConfig{
+		Commands: iot.GetCommands(),
+		Cmd:      "scw iot network list -o human=ID,Name,Type",
+		Check:    core.TestCheckGolden(),
+	}))
+}
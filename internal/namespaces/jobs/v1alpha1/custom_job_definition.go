@@ -27,8 +27,8 @@ func definitionStartBuilder(c *core.Command) *core.Command {
 		return api.WaitForJobRun(&jobs.WaitForJobRunRequest{
 			Region:        args.Region,
 			JobRunID:      resp.ID,
-			Timeout:       jobDefinition.JobTimeout.ToTimeDuration(),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, jobDefinition.JobTimeout.ToTimeDuration()),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -29,6 +29,8 @@ func jobsRunWait() *core.Command {
 		},
 		Run: func(ctx context.Context, args interface{}) (i interface{}, e error) {
 			request := args.(*jobs.WaitForJobRunRequest)
+			request.Timeout = core.WaitTimeout(ctx, request.Timeout)
+			request.RetryInterval = core.WaitRetryInterval(ctx)
 
 			client := core.ExtractClient(ctx)
 			api := jobs.NewAPI(client)
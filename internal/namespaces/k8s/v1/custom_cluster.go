@@ -347,8 +347,8 @@ func waitForClusterFunc(action int) core.WaitFunc {
 		cluster, err := k8s.NewAPI(core.ExtractClient(ctx)).WaitForCluster(&k8s.WaitForClusterRequest{
 			Region:        clusterResponse.Region,
 			ClusterID:     clusterResponse.ID,
-			Timeout:       scw.TimeDurationPtr(clusterActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(clusterActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		switch action {
 		case clusterActionCreate:
@@ -391,8 +391,8 @@ func k8sClusterWaitCommand() *core.Command {
 			cluster, err := api.WaitForCluster(&k8s.WaitForClusterRequest{
 				Region:        args.Region,
 				ClusterID:     args.ClusterID,
-				Timeout:       args.Timeout,
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, args.Timeout),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 			if err != nil {
 				return nil, err
@@ -410,8 +410,8 @@ func k8sClusterWaitCommand() *core.Command {
 					_, err := api.WaitForPool(&k8s.WaitForPoolRequest{
 						Region:        pool.Region,
 						PoolID:        pool.ID,
-						Timeout:       args.Timeout,
-						RetryInterval: core.DefaultRetryInterval,
+						Timeout:       core.WaitTimeout(ctx, args.Timeout),
+						RetryInterval: core.WaitRetryInterval(ctx),
 					})
 					if err != nil {
 						return cluster, err
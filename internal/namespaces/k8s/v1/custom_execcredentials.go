@@ -8,6 +8,7 @@ import (
 	"reflect"
 
 	"github.com/scaleway/scaleway-cli/v2/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/profiles"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/scaleway-sdk-go/validation"
 )
@@ -24,29 +25,15 @@ func k8sExecCredentialCommand() *core.Command {
 
 		// avoid calling checkAPIKey (Check if API Key is about to expire)
 		DisableAfterChecks: true,
+		// kubectl runs it before each request
+		DisableHistory: true,
 	}
 }
 
 func k8sExecCredentialRun(ctx context.Context, _ interface{}) (i interface{}, e error) {
-	config, _ := scw.LoadConfigFromPath(core.ExtractConfigPath(ctx))
-	profileName := core.ExtractProfileName(ctx)
-
-	var token string
-	switch {
-	// Environment variable check
-	case core.ExtractEnv(ctx, scw.ScwSecretKeyEnv) != "":
-		token = core.ExtractEnv(ctx, scw.ScwSecretKeyEnv)
-	// There is no config file
-	case config == nil:
-		return nil, errors.New("config not provided")
-	// Config file with profile name
-	case config.Profiles[profileName] != nil && config.Profiles[profileName].SecretKey != nil:
-		token = *config.Profiles[profileName].SecretKey
-	// Default config
-	case config.Profile.SecretKey != nil:
-		token = *config.Profile.SecretKey
-	default:
-		return nil, errors.New("unable to find secret key")
+	token, err := execCredentialSecretKey(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	if !validation.IsSecretKey(token) {
@@ -68,6 +55,38 @@ func k8sExecCredentialRun(ctx context.Context, _ interface{}) (i interface{}, e
 	return string(response), nil
 }
 
+// execCredentialSecretKey returns the secret key of the active profile, including the one it inherits from the profiles it extends.
+// The secret_key_command of the profile is run like when the client of the CLI is created.
+func execCredentialSecretKey(ctx context.Context) (string, error) {
+	// Environment variable check
+	if token := core.ExtractEnv(ctx, scw.ScwSecretKeyEnv); token != "" {
+		return token, nil
+	}
+
+	config, extensions, err := profiles.LoadConfig(core.ExtractConfigPath(ctx))
+	if err != nil {
+		return "", fmt.Errorf("config not provided: %w", err)
+	}
+
+	profileName := core.ExtractProfileName(ctx)
+	profile, _, err := profiles.Resolve(config, extensions, profileName)
+	if err != nil {
+		return "", err
+	}
+	if profile.SecretKey != nil {
+		return *profile.SecretKey, nil
+	}
+
+	extension, origin, err := profiles.SecretKeyCommand(config, extensions, profileName)
+	if err != nil {
+		return "", err
+	}
+	if extension == nil {
+		return "", errors.New("unable to find secret key")
+	}
+	return core.SecretKeyFromCommand(ctx, origin, extension)
+}
+
 // ExecCredential is used by exec-based plugins to communicate credentials to HTTP transports.
 type ExecCredential struct {
 	// APIVersion defines the versioned schema of this representation of an object.
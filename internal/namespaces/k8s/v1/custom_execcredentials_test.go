@@ -2,7 +2,9 @@ package k8s_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"os/exec"
 	"path"
 	"testing"
 
@@ -16,6 +18,7 @@ const (
 	p1Secret  = "00000000-0000-0000-0000-111111111111"
 	p2Secret  = "00000000-0000-0000-0000-222222222222"
 	p3Secret  = "00000000-0000-0000-0000-333333333333"
+	p5Secret  = "00000000-0000-0000-0000-555555555555"
 	envSecret = "66666666-6666-6666-6666-666666666666"
 )
 
@@ -105,6 +108,77 @@ func Test_ExecCredential(t *testing.T) {
 			assertTokenInResponse(p3Secret),
 		),
 	}))
+
+	// expect to return p2 secret_key inherited by p4
+	t.Run("with extending profile", core.Test(&core.TestConfig{
+		Commands:   k8s.GetCommands(),
+		TmpHomeDir: true,
+		BeforeFunc: beforeFuncWriteConfigFile(extendedConfig),
+		Cmd:        "scw --profile p4 k8s exec-credential",
+		OverrideEnv: map[string]string{
+			scw.ScwAccessKeyEnv: "", // Ignore keys in test env
+			scw.ScwSecretKeyEnv: "", // Ignore keys in test env
+		},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGolden(),
+			assertTokenInResponse(p2Secret),
+		),
+	}))
+
+	// expect to return the output of the secret_key_command inherited by p6
+	t.Run("with secret key command", core.Test(&core.TestConfig{
+		Commands:   k8s.GetCommands(),
+		TmpHomeDir: true,
+		BeforeFunc: beforeFuncWriteConfigFile(extendedConfig),
+		Cmd:        "scw --profile p6 k8s exec-credential",
+		OverrideEnv: map[string]string{
+			scw.ScwAccessKeyEnv: "", // Ignore keys in test env
+			scw.ScwSecretKeyEnv: "", // Ignore keys in test env
+		},
+		OverrideExec: func(_ *core.ExecFuncCtx, cmd *exec.Cmd) (int, error) {
+			_, err := fmt.Fprintln(cmd.Stdout, p5Secret)
+			return 0, err
+		},
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			core.TestCheckGolden(),
+			assertTokenInResponse(p5Secret),
+		),
+	}))
+}
+
+// extendedConfig has a p4 profile inheriting the secret key of the p2 profile
+// and a p6 profile inheriting the secret_key_command of the p5 profile.
+const extendedConfig = `access_key: SCWXXXXXXXXXXXXXXXXX
+secret_key: ` + p1Secret + `
+default_organization_id: deadbeef-dead-dead-dead-deaddeafbeef
+default_region: fr-par
+default_zone: fr-par-1
+profiles:
+  p2:
+    access_key: SCWP2XXXXXXXXXXXXXXX
+    secret_key: ` + p2Secret + `
+  p4:
+    extends: p2
+    default_zone: fr-par-2
+  p5:
+    access_key: SCWP5XXXXXXXXXXXXXXX
+    secret_key_command: pass show scw/p5
+  p6:
+    extends: p5
+`
+
+func beforeFuncWriteConfigFile(content string) core.BeforeFunc {
+	return func(ctx *core.BeforeFuncCtx) error {
+		scwDir := path.Join(ctx.OverrideEnv["HOME"], ".config", "scw")
+		err := os.MkdirAll(scwDir, 0o0755)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(path.Join(scwDir, "config.yaml"), []byte(content), 0o600)
+	}
 }
 
 func beforeFuncCreateConfigFile(c *scw.Config) core.BeforeFunc {
@@ -31,10 +31,11 @@ func k8sKubeconfigInstallCommand() *core.Command {
 		Short: `Install a kubeconfig`,
 		Long: `Retrieve the kubeconfig for a specified cluster and write it on disk. 
 It will merge the new kubeconfig in the file pointed by the KUBECONFIG variable. If empty it will default to $HOME/.kube/config.`,
-		Namespace: "k8s",
-		Verb:      "install",
-		Resource:  "kubeconfig",
-		ArgsType:  reflect.TypeOf(k8sKubeconfigInstallRequest{}),
+		Namespace:    "k8s",
+		Verb:         "install",
+		LocalEffects: true,
+		Resource:     "kubeconfig",
+		ArgsType:     reflect.TypeOf(k8sKubeconfigInstallRequest{}),
 		ArgSpecs: core.ArgSpecs{
 			{
 				Name:       "cluster-id",
@@ -20,10 +20,11 @@ func k8sKubeconfigUninstallCommand() *core.Command {
 		Short: `Uninstall a kubeconfig`,
 		Long: `Remove specified cluster from kubeconfig file specified by the KUBECONFIG env, if empty it will default to $HOME/.kube/config.
 If the current context points to this cluster, it will be set to an empty context.`,
-		Namespace: "k8s",
-		Verb:      "uninstall",
-		Resource:  "kubeconfig",
-		ArgsType:  reflect.TypeOf(k8sKubeconfigUninstallRequest{}),
+		Namespace:    "k8s",
+		Verb:         "uninstall",
+		LocalEffects: true,
+		Resource:     "kubeconfig",
+		ArgsType:     reflect.TypeOf(k8sKubeconfigUninstallRequest{}),
 		ArgSpecs: core.ArgSpecs{
 			{
 				Name:       "cluster-id",
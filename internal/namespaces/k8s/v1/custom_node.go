@@ -40,8 +40,8 @@ func waitForNodeFunc(action int) core.WaitFunc {
 		node, err := k8s.NewAPI(core.ExtractClient(ctx)).WaitForNode(&k8s.WaitForNodeRequest{
 			Region:        respI.(*k8s.Node).Region,
 			NodeID:        respI.(*k8s.Node).ID,
-			Timeout:       scw.TimeDurationPtr(nodeActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(nodeActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		if action == nodeActionReboot {
 			return node, err
@@ -64,8 +64,8 @@ func k8sNodeWaitCommand() *core.Command {
 			return api.WaitForNode(&k8s.WaitForNodeRequest{
 				Region:        argsI.(*k8s.WaitForNodeRequest).Region,
 				NodeID:        argsI.(*k8s.WaitForNodeRequest).NodeID,
-				Timeout:       argsI.(*k8s.WaitForNodeRequest).Timeout,
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, argsI.(*k8s.WaitForNodeRequest).Timeout),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 		},
 		ArgSpecs: core.ArgSpecs{
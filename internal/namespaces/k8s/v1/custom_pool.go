@@ -70,8 +70,8 @@ func waitForPoolFunc(action int) core.WaitFunc {
 		pool, err := k8s.NewAPI(core.ExtractClient(ctx)).WaitForPool(&k8s.WaitForPoolRequest{
 			Region:        respI.(*k8s.Pool).Region,
 			PoolID:        respI.(*k8s.Pool).ID,
-			Timeout:       scw.TimeDurationPtr(poolActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(poolActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		switch action {
 		case poolActionCreate:
@@ -108,8 +108,8 @@ func k8sPoolWaitCommand() *core.Command {
 			return api.WaitForPool(&k8s.WaitForPoolRequest{
 				Region:        argsI.(*k8s.WaitForPoolRequest).Region,
 				PoolID:        argsI.(*k8s.WaitForPoolRequest).PoolID,
-				Timeout:       argsI.(*k8s.WaitForPoolRequest).Timeout,
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, argsI.(*k8s.WaitForPoolRequest).Timeout),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 		},
 		ArgSpecs: core.ArgSpecs{
@@ -23,6 +23,7 @@ func plaintextEncrypt(c *core.Command) *core.Command {
 		Short:       "Base64 Plaintext data to encrypt",
 		Required:    true,
 		CanLoadFile: true,
+		Secret:      true,
 	}
 
 	c.Interceptor = func(ctx context.Context, argsI interface{}, runner core.CommandRunner) (interface{}, error) {
@@ -77,8 +77,8 @@ func lbWaitCommand() *core.Command {
 			return api.WaitForLb(&lb.ZonedAPIWaitForLBRequest{
 				LBID:          args.LBID,
 				Zone:          args.Zone,
-				RetryInterval: core.DefaultRetryInterval,
-				Timeout:       args.Timeout,
+				RetryInterval: core.WaitRetryInterval(ctx),
+				Timeout:       core.WaitTimeout(ctx, args.Timeout),
 			})
 		},
 		ArgSpecs: core.ArgSpecs{
@@ -113,7 +113,8 @@ func lbCreateBuilder(c *core.Command) *core.Command {
 		return api.WaitForLb(&lb.ZonedAPIWaitForLBRequest{
 			LBID:          respI.(*lb.LB).ID,
 			Zone:          respI.(*lb.LB).Zone,
-			RetryInterval: core.DefaultRetryInterval,
+			RetryInterval: core.WaitRetryInterval(ctx),
+			Timeout:       core.WaitTimeout(ctx, nil),
 		})
 	}
 	return c
@@ -168,8 +169,8 @@ func lbUpdateBuilder(c *core.Command) *core.Command {
 		waitRequest := &lb.ZonedAPIWaitForLBRequest{
 			LBID:          request.LBID,
 			Zone:          request.Zone,
-			Timeout:       scw.TimeDurationPtr(defaultLBTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(defaultLBTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		}
 		res, err := lbAPI.WaitForLb(waitRequest, scw.WithContext(ctx))
 		if err != nil {
@@ -230,7 +231,8 @@ func lbDeleteBuilder(c *core.Command) *core.Command {
 		waitForLb, err := api.WaitForLb(&lb.ZonedAPIWaitForLBRequest{
 			LBID:          argsI.(*lb.ZonedAPIDeleteLBRequest).LBID,
 			Zone:          argsI.(*lb.ZonedAPIDeleteLBRequest).Zone,
-			RetryInterval: core.DefaultRetryInterval,
+			RetryInterval: core.WaitRetryInterval(ctx),
+			Timeout:       core.WaitTimeout(ctx, nil),
 		})
 		if err != nil {
 			notFoundError := &scw.ResourceNotFoundError{}
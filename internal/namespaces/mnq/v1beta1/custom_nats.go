@@ -28,11 +28,12 @@ type CreateContextRequest struct {
 
 func createContextCommand() *core.Command {
 	return &core.Command{
-		Short:     "Create a new context for natscli",
-		Namespace: "mnq",
-		Resource:  "nats",
-		Verb:      "create-context",
-		Groups:    []string{"workflow"},
+		Short:        "Create a new context for natscli",
+		Namespace:    "mnq",
+		Resource:     "nats",
+		Verb:         "create-context",
+		LocalEffects: true,
+		Groups:       []string{"workflow"},
 		Long: `This command help you configure your nats cli
 Contexts should are stored in $HOME/.config/nats/context
 Credentials and context file are saved in your nats context folder with 0600 permissions`,
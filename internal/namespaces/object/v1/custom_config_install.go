@@ -21,12 +21,13 @@ func configInstallCommand() *core.Command {
 		Name   string
 	}
 	return &core.Command{
-		Namespace: "object",
-		Resource:  "config",
-		Verb:      "install",
-		Short:     "Install a S3 tool configuration file to its default location",
-		Long:      "Install a S3 tool configuration file to its default location.",
-		ArgsType:  reflect.TypeOf(installArgs{}),
+		Namespace:    "object",
+		Resource:     "config",
+		Verb:         "install",
+		LocalEffects: true,
+		Short:        "Install a S3 tool configuration file to its default location",
+		Long:         "Install a S3 tool configuration file to its default location.",
+		ArgsType:     reflect.TypeOf(installArgs{}),
 		ArgSpecs: []*core.ArgSpec{
 			{
 				Name:       "type",
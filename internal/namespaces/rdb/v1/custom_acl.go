@@ -114,8 +114,8 @@ func aclAddBuilder(c *core.Command) *core.Command {
 		_, err := api.WaitForInstance(&rdb.WaitForInstanceRequest{
 			InstanceID:    args.InstanceID,
 			Region:        args.Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(instanceActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			return nil, err
@@ -217,8 +217,8 @@ func aclDeleteBuilder(c *core.Command) *core.Command {
 		_, err := api.WaitForInstance(&rdb.WaitForInstanceRequest{
 			InstanceID:    args.InstanceID,
 			Region:        args.Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(instanceActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			return nil, err
@@ -303,8 +303,8 @@ func aclSetBuilder(c *core.Command) *core.Command {
 		_, err := api.WaitForInstance(&rdb.WaitForInstanceRequest{
 			InstanceID:    args.InstanceID,
 			Region:        args.Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(instanceActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			return nil, err
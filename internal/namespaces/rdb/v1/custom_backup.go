@@ -54,8 +54,8 @@ func backupWaitCommand() *core.Command {
 			return api.WaitForDatabaseBackup(&rdb.WaitForDatabaseBackupRequest{
 				DatabaseBackupID: argsI.(*backupWaitRequest).BackupID,
 				Region:           argsI.(*backupWaitRequest).Region,
-				Timeout:          scw.TimeDurationPtr(argsI.(*backupWaitRequest).Timeout),
-				RetryInterval:    core.DefaultRetryInterval,
+				Timeout:          core.WaitTimeout(ctx, scw.TimeDurationPtr(argsI.(*backupWaitRequest).Timeout)),
+				RetryInterval:    core.WaitRetryInterval(ctx),
 			})
 		},
 		ArgSpecs: core.ArgSpecs{
@@ -84,8 +84,8 @@ func backupCreateBuilder(c *core.Command) *core.Command {
 		return api.WaitForDatabaseBackup(&rdb.WaitForDatabaseBackupRequest{
 			DatabaseBackupID: respI.(*rdb.DatabaseBackup).ID,
 			Region:           respI.(*rdb.DatabaseBackup).Region,
-			Timeout:          &timeout,
-			RetryInterval:    core.DefaultRetryInterval,
+			Timeout:          core.WaitTimeout(ctx, &timeout),
+			RetryInterval:    core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -99,8 +99,8 @@ func backupExportBuilder(c *core.Command) *core.Command {
 		return api.WaitForDatabaseBackup(&rdb.WaitForDatabaseBackupRequest{
 			DatabaseBackupID: respI.(*rdb.DatabaseBackup).ID,
 			Region:           respI.(*rdb.DatabaseBackup).Region,
-			Timeout:          &timeout,
-			RetryInterval:    core.DefaultRetryInterval,
+			Timeout:          core.WaitTimeout(ctx, &timeout),
+			RetryInterval:    core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -114,8 +114,8 @@ func backupRestoreBuilder(c *core.Command) *core.Command {
 		return api.WaitForDatabaseBackup(&rdb.WaitForDatabaseBackupRequest{
 			DatabaseBackupID: respI.(*rdb.DatabaseBackup).ID,
 			Region:           respI.(*rdb.DatabaseBackup).Region,
-			Timeout:          &timeout,
-			RetryInterval:    core.DefaultRetryInterval,
+			Timeout:          core.WaitTimeout(ctx, &timeout),
+			RetryInterval:    core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -272,20 +272,21 @@ func backupDownloadCommand() *core.Command {
 	}
 
 	return &core.Command{
-		Short:     `Download a backup locally`,
-		Long:      `Download a backup locally.`,
-		Namespace: "rdb",
-		Resource:  "backup",
-		Verb:      "download",
-		ArgsType:  reflect.TypeOf(backupDownloadArgs{}),
+		Short:        `Download a backup locally`,
+		Long:         `Download a backup locally.`,
+		Namespace:    "rdb",
+		Resource:     "backup",
+		Verb:         "download",
+		LocalEffects: true,
+		ArgsType:     reflect.TypeOf(backupDownloadArgs{}),
 		Run: func(ctx context.Context, argsI interface{}) (i interface{}, err error) {
 			args := argsI.(*backupDownloadArgs)
 			api := rdb.NewAPI(core.ExtractClient(ctx))
 			backupRequest := &rdb.WaitForDatabaseBackupRequest{
 				DatabaseBackupID: args.BackupID,
 				Region:           args.Region,
-				Timeout:          scw.TimeDurationPtr(backupActionTimeout),
-				RetryInterval:    core.DefaultRetryInterval,
+				Timeout:          core.WaitTimeout(ctx, scw.TimeDurationPtr(backupActionTimeout)),
+				RetryInterval:    core.WaitRetryInterval(ctx),
 			}
 
 			backup, err := api.WaitForDatabaseBackup(backupRequest)
@@ -308,6 +309,8 @@ func backupDownloadCommand() *core.Command {
 				}
 			}
 
+			// The first wait took part of the time left before the --timeout deadline.
+			backupRequest.Timeout = core.WaitTimeout(ctx, scw.TimeDurationPtr(backupActionTimeout))
 			backup, err = api.WaitForDatabaseBackup(backupRequest)
 			if err != nil {
 				return nil, err
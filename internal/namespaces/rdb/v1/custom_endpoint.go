@@ -122,8 +122,10 @@ func endpointCreateBuilder(c *core.Command) *core.Command {
 		args := argsI.(*rdbCreateEndpointRequestCustom)
 
 		instance, err := api.WaitForInstance(&rdb.WaitForInstanceRequest{
-			InstanceID: args.InstanceID,
-			Region:     args.Region,
+			InstanceID:    args.InstanceID,
+			Region:        args.Region,
+			Timeout:       core.WaitTimeout(ctx, nil),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		}, scw.WithContext(ctx))
 		if err != nil {
 			return nil, err
@@ -190,8 +192,10 @@ func endpointDeleteBuilder(c *core.Command) *core.Command {
 		args := argsI.(*rdbDeleteEndpointRequestCustom)
 
 		_, err := api.WaitForInstance(&rdb.WaitForInstanceRequest{
-			InstanceID: args.InstanceID,
-			Region:     args.Region,
+			InstanceID:    args.InstanceID,
+			Region:        args.Region,
+			Timeout:       core.WaitTimeout(ctx, nil),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		}, scw.WithContext(ctx))
 		if err != nil {
 			return nil, err
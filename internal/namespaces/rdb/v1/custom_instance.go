@@ -162,8 +162,8 @@ func instanceCloneBuilder(c *core.Command) *core.Command {
 		return api.WaitForInstance(&rdbSDK.WaitForInstanceRequest{
 			InstanceID:    respI.(*rdbSDK.Instance).ID,
 			Region:        respI.(*rdbSDK.Instance).Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(instanceActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -266,8 +266,8 @@ func instanceCreateBuilder(c *core.Command) *core.Command {
 		instance, err := api.WaitForInstance(&rdbSDK.WaitForInstanceRequest{
 			InstanceID:    respI.(CreateInstanceResult).Instance.ID,
 			Region:        respI.(CreateInstanceResult).Instance.Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(instanceActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			return nil, err
@@ -391,8 +391,8 @@ func instanceUpgradeBuilder(c *core.Command) *core.Command {
 		return api.WaitForInstance(&rdbSDK.WaitForInstanceRequest{
 			InstanceID:    respI.(*rdbSDK.Instance).ID,
 			Region:        respI.(*rdbSDK.Instance).Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(instanceActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 
@@ -548,8 +548,8 @@ func instanceUpdateBuilder(_ *core.Command) *core.Command {
 			return api.WaitForInstance(&rdbSDK.WaitForInstanceRequest{
 				InstanceID:    respI.(*rdbSDK.Instance).ID,
 				Region:        respI.(*rdbSDK.Instance).Region,
-				Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(instanceActionTimeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 		},
 		Examples: []*core.Example{
@@ -575,8 +575,8 @@ func instanceDeleteBuilder(c *core.Command) *core.Command {
 		instance, err := api.WaitForInstance(&rdbSDK.WaitForInstanceRequest{
 			InstanceID:    respI.(*rdbSDK.Instance).ID,
 			Region:        respI.(*rdbSDK.Instance).Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(instanceActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			// if we get a 404 here, it means the resource was successfully deleted
@@ -606,8 +606,8 @@ func instanceWaitCommand() *core.Command {
 			return api.WaitForInstance(&rdbSDK.WaitForInstanceRequest{
 				Region:        argsI.(*serverWaitRequest).Region,
 				InstanceID:    argsI.(*serverWaitRequest).InstanceID,
-				Timeout:       scw.TimeDurationPtr(argsI.(*serverWaitRequest).Timeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(argsI.(*serverWaitRequest).Timeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 		},
 		ArgSpecs: core.ArgSpecs{
@@ -760,12 +760,13 @@ func createConnectCommandLineArgs(endpoint *rdbSDK.Endpoint, family engineFamily
 
 func instanceConnectCommand() *core.Command {
 	return &core.Command{
-		Namespace: "rdb",
-		Resource:  "instance",
-		Verb:      "connect",
-		Short:     "Connect to an instance using locally installed CLI",
-		Long:      "Connect to an instance using locally installed CLI such as psql or mysql.",
-		ArgsType:  reflect.TypeOf(instanceConnectArgs{}),
+		Namespace:    "rdb",
+		Resource:     "instance",
+		Verb:         "connect",
+		LocalEffects: true,
+		Short:        "Connect to an instance using locally installed CLI",
+		Long:         "Connect to an instance using locally installed CLI such as psql or mysql.",
+		ArgsType:     reflect.TypeOf(instanceConnectArgs{}),
 		ArgSpecs: core.ArgSpecs{
 			{
 				Name:     "private-network",
@@ -26,8 +26,8 @@ func logPrepareBuilder(c *core.Command) *core.Command {
 			logs, err := api.WaitForInstanceLog(&rdb.WaitForInstanceLogRequest{
 				InstanceLogID: getResp.InstanceLogs[i].ID,
 				Region:        getResp.InstanceLogs[i].Region,
-				Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(instanceActionTimeout)),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 			if err != nil {
 				return nil, err
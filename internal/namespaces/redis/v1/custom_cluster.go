@@ -50,8 +50,8 @@ func clusterCreateBuilder(c *core.Command) *core.Command {
 		cluster, err := api.WaitForCluster(&redis.WaitForClusterRequest{
 			ClusterID:     respI.(*redis.Cluster).ID,
 			Zone:          respI.(*redis.Cluster).Zone,
-			Timeout:       scw.TimeDurationPtr(redisActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(redisActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			return nil, err
@@ -99,8 +99,8 @@ func clusterDeleteBuilder(c *core.Command) *core.Command {
 		cluster, err := api.WaitForCluster(&redis.WaitForClusterRequest{
 			ClusterID:     respI.(*redis.Cluster).ID,
 			Zone:          respI.(*redis.Cluster).Zone,
-			Timeout:       scw.TimeDurationPtr(redisActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(redisActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			// if we get a 404 here, it means the resource was successfully deleted
@@ -129,8 +129,8 @@ func clusterWaitCommand() *core.Command {
 			return api.WaitForCluster(&redis.WaitForClusterRequest{
 				Zone:          argsI.(*redis.WaitForClusterRequest).Zone,
 				ClusterID:     argsI.(*redis.WaitForClusterRequest).ClusterID,
-				Timeout:       argsI.(*redis.WaitForClusterRequest).Timeout,
-				RetryInterval: core.DefaultRetryInterval,
+				Timeout:       core.WaitTimeout(ctx, argsI.(*redis.WaitForClusterRequest).Timeout),
+				RetryInterval: core.WaitRetryInterval(ctx),
 			})
 		},
 		ArgSpecs: core.ArgSpecs{
@@ -37,9 +37,10 @@ It will create a new script named docker-credential-scw.
 This script will be called each time Docker needs the credentials and will return the correct credentials.
 It avoid running docker login commands.
 `,
-		Namespace: "registry",
-		Resource:  "install-docker-helper",
-		ArgsType:  reflect.TypeOf(registrySetupDockerHelperArgs{}),
+		Namespace:    "registry",
+		Resource:     "install-docker-helper",
+		LocalEffects: true,
+		ArgsType:     reflect.TypeOf(registrySetupDockerHelperArgs{}),
 		ArgSpecs: []*core.ArgSpec{
 			{
 				Name:    "path",
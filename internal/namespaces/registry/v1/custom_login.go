@@ -21,9 +21,10 @@ func registryLoginCommand() *core.Command {
 		Short: `Login to a registry`,
 		Long: `This command will run the correct command in order to log you in on the registry with the chosen program.
 You will need to have the chosen binary installed on your system and in your PATH.`,
-		Namespace: "registry",
-		Resource:  "login",
-		ArgsType:  reflect.TypeOf(registryLoginArgs{}),
+		Namespace:    "registry",
+		Resource:     "login",
+		LocalEffects: true,
+		ArgsType:     reflect.TypeOf(registryLoginArgs{}),
 		ArgSpecs: []*core.ArgSpec{
 			{
 				Name:       "program",
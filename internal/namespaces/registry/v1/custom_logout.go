@@ -19,9 +19,10 @@ func registryLogoutCommand() *core.Command {
 		Short: `Logout of a registry`,
 		Long: `This command will run the correct command in order to log you out of the registry with the chosen program.
 You will need to have the chosen binary installed on your system and in your PATH.`,
-		Namespace: "registry",
-		Resource:  "logout",
-		ArgsType:  reflect.TypeOf(registryLogoutArgs{}),
+		Namespace:    "registry",
+		Resource:     "logout",
+		LocalEffects: true,
+		ArgsType:     reflect.TypeOf(registryLogoutArgs{}),
 		ArgSpecs: []*core.ArgSpec{
 			{
 				Name:       "program",
@@ -16,6 +16,7 @@ func secretVersionCreateBuilder(c *core.Command) *core.Command {
 		Short:       "Content of the secret version.",
 		Required:    true,
 		CanLoadFile: true,
+		Secret:      true,
 	}
 
 	c.Examples = append(c.Examples, &core.Example{
@@ -1,11 +1,14 @@
 package secret_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/alecthomas/assert"
 	"github.com/scaleway/scaleway-cli/v2/core"
 	secret "github.com/scaleway/scaleway-cli/v2/internal/namespaces/secret/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 func Test_AccessSecret(t *testing.T) {
@@ -34,3 +37,21 @@ func Test_AccessSecret(t *testing.T) {
 		),
 	}))
 }
+
+func Test_CreateSecretVersionHistory(t *testing.T) {
+	t.Run("Data is redacted", core.Test(&core.TestConfig{
+		Commands:   secret.GetCommands(),
+		TmpHomeDir: true,
+		Cmd:        "scw secret version create 11111111-1111-1111-1111-111111111111 data=hunter2 --dry-run",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			func(t *testing.T, ctx *core.CheckFuncCtx) {
+				t.Helper()
+				content, err := os.ReadFile(filepath.Join(ctx.OverrideEnv[scw.ScwCacheDirEnv], "history.jsonl"))
+				assert.NoError(t, err)
+				assert.Contains(t, string(content), `"args":["secret","version","create","11111111-1111-1111-1111-111111111111","data=<redacted>","--dry-run"],"redacted":true`)
+				assert.NotContains(t, string(content), "hunter2")
+			},
+		),
+	}))
+}
@@ -34,8 +34,8 @@ func gatewayCreateBuilder(c *core.Command) *core.Command {
 		return api.WaitForGateway(&vpcgw.WaitForGatewayRequest{
 			GatewayID:     getResp.ID,
 			Zone:          getResp.Zone,
-			Timeout:       scw.TimeDurationPtr(gatewayActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
+			Timeout:       core.WaitTimeout(ctx, scw.TimeDurationPtr(gatewayActionTimeout)),
+			RetryInterval: core.WaitRetryInterval(ctx),
 		})
 	}
 	return c
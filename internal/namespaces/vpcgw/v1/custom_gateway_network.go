@@ -29,8 +29,8 @@ func gatewayNetworkCreateBuilder(c *core.Command) *core.Command {
 		return api.WaitForGatewayNetwork(&vpcgw.WaitForGatewayNetworkRequest{
 			GatewayNetworkID: getResp.ID,
 			Zone:             getResp.Zone,
-			Timeout:          scw.TimeDurationPtr(gatewayActionTimeout),
-			RetryInterval:    core.DefaultRetryInterval,
+			Timeout:          core.WaitTimeout(ctx, scw.TimeDurationPtr(gatewayActionTimeout)),
+			RetryInterval:    core.WaitRetryInterval(ctx),
 		})
 	}
 	return c
@@ -43,8 +43,8 @@ func gatewayNetworkDeleteBuilder(c *core.Command) *core.Command {
 		gwNetwork, err := api.WaitForGatewayNetwork(&vpcgw.WaitForGatewayNetworkRequest{
 			GatewayNetworkID: getResp.GatewayNetworkID,
 			Zone:             getResp.Zone,
-			Timeout:          scw.TimeDurationPtr(gatewayActionTimeout),
-			RetryInterval:    core.DefaultRetryInterval,
+			Timeout:          core.WaitTimeout(ctx, scw.TimeDurationPtr(gatewayActionTimeout)),
+			RetryInterval:    core.WaitRetryInterval(ctx),
 		})
 		if err != nil {
 			notFoundError := &scw.ResourceNotFoundError{}
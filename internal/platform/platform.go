@@ -3,15 +3,22 @@ package platform
 import (
 	"net/http"
 
+	"github.com/scaleway/scaleway-cli/v2/internal/profiles"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
+// SecretKeyFunc returns the secret key of a profile from the output of its secret_key_command.
+// profileName is the profile setting the command, it can be a profile extended by the active one.
+type SecretKeyFunc func(profileName string, extension *profiles.Extension) (string, error)
+
 // Platform defines an environment running the CLI
 // It can be the implementation to run in a terminal
 // Or the implementation to run in a browser (used for wasm/js build)
 type Platform interface {
 	// CreateClient returns a valid client for the current platform
-	CreateClient(client *http.Client, configPath string, profileName string) (*scw.Client, error)
+	// secretKey is called when the profile sets a secret_key_command instead of a secret_key
+	// overrides have priority over the values of the profile but not over the environment, e.g. the values of a project config file
+	CreateClient(client *http.Client, configPath string, profileName string, secretKey SecretKeyFunc, overrides *scw.Profile) (*scw.Client, error)
 
 	// ScwConfig returns a scaleway config if available, can be nil
 	// TODO: remove if possible, currently used in profile completion
@@ -7,13 +7,17 @@ import (
 	"strings"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/platform"
+	"github.com/scaleway/scaleway-cli/v2/internal/profiles"
 	"github.com/scaleway/scaleway-sdk-go/logger"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/scaleway-sdk-go/validation"
 )
 
-func (p *Platform) CreateClient(httpClient *http.Client, configPath string, profileName string) (*scw.Client, error) {
-	profile := scw.LoadEnvProfile()
+func (p *Platform) CreateClient(httpClient *http.Client, configPath string, profileName string, secretKey platform.SecretKeyFunc, overrides *scw.Profile) (*scw.Client, error) {
+	if overrides == nil {
+		overrides = &scw.Profile{}
+	}
+	profile := scw.MergeProfiles(overrides, scw.LoadEnvProfile())
 
 	// Default path is based on the following priority order:
 	// * The config file's path provided via --config flag
@@ -21,7 +25,7 @@ func (p *Platform) CreateClient(httpClient *http.Client, configPath string, prof
 	// * $XDG_CONFIG_HOME/scw/config.yaml
 	// * $HOME/.config/scw/config.yaml
 	// * $USERPROFILE/.config/scw/config.yaml
-	config, err := scw.LoadConfigFromPath(configPath)
+	config, extensions, err := profiles.LoadConfig(configPath)
 	switch {
 	case errIsConfigFileNotFound(err):
 		// no config file was found -> nop
@@ -34,12 +38,27 @@ func (p *Platform) CreateClient(httpClient *http.Client, configPath string, prof
 		// Store latest version of config in platform
 		p.cfg = config
 
-		// found and loaded a config file -> merge with env
-		activeProfile, err := config.GetProfile(profileName)
+		// found and loaded a config file -> resolve the profiles it extends and merge with env
+		activeProfile, _, err := profiles.Resolve(config, extensions, profileName)
 		if err != nil {
 			return nil, err
 		}
 
+		// The secret key can be the output of a command, it is not run when the environment sets the secret key
+		if profile.SecretKey == nil && secretKey != nil {
+			extension, origin, err := profiles.SecretKeyCommand(config, extensions, profileName)
+			if err != nil {
+				return nil, err
+			}
+			if extension != nil {
+				key, err := secretKey(origin, extension)
+				if err != nil {
+					return nil, err
+				}
+				activeProfile.SecretKey = &key
+			}
+		}
+
 		// Creates a client from the active profile
 		// It will trigger a validation step on its configuration to catch errors if any
 		opts := []scw.ClientOption{
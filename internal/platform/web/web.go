@@ -3,6 +3,7 @@ package web
 import (
 	"net/http"
 
+	"github.com/scaleway/scaleway-cli/v2/internal/platform"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
@@ -14,7 +15,7 @@ type Platform struct {
 	APIUrl                string
 }
 
-func (p *Platform) CreateClient(client *http.Client, _ string, _ string) (*scw.Client, error) {
+func (p *Platform) CreateClient(client *http.Client, _ string, _ string, _ platform.SecretKeyFunc, _ *scw.Profile) (*scw.Client, error) {
 	opts := []scw.ClientOption{
 		scw.WithDefaultRegion(scw.RegionFrPar),
 		scw.WithDefaultZone(scw.ZoneFrPar1),
@@ -0,0 +1,373 @@
+// Package profiles handles the keys of the config file profiles that are specific to the CLI, e.g. extends or secret_key_command.
+// The SDK ignores these keys when it loads the config file and drops them when it saves it.
+package profiles
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"gopkg.in/yaml.v3"
+)
+
+// SecretKeyCacheProcess keeps the output of a secret_key_command for the lifetime of the CLI process, e.g. in shell mode.
+// Secret keys are never cached on disk, so that they are not kept in plain text files.
+const SecretKeyCacheProcess = "process"
+
+// SecretKeyCaches are the valid values of the secret_key_cache key.
+var SecretKeyCaches = []string{SecretKeyCacheProcess}
+
+// Extension holds the keys of a profile that are specific to the CLI.
+type Extension struct {
+	// Extends is the name of the profile whose values are inherited by this profile.
+	Extends string `yaml:"extends,omitempty" json:"extends,omitempty"`
+	// SecretKeyCommand is a command line whose output is the secret key of the profile, e.g. pass show scw/prod.
+	SecretKeyCommand string `yaml:"secret_key_command,omitempty" json:"secret_key_command,omitempty"`
+	// SecretKeyCache is how long the output of SecretKeyCommand is kept, see SecretKeyCaches. It is run every time when empty.
+	SecretKeyCache string `yaml:"secret_key_cache,omitempty" json:"secret_key_cache,omitempty"`
+}
+
+// Extensions holds the extensions of the profiles of a config file.
+type Extensions struct {
+	// Default is the extension of the default profile, its keys are at the top level of the config file.
+	Default  Extension             `yaml:",inline"`
+	Profiles map[string]*Extension `yaml:"profiles,omitempty"`
+}
+
+// Origins maps the keys of a resolved profile, e.g. access_key, to the name of the profile they come from.
+type Origins map[string]string
+
+// Get returns the extension of a profile, it is never nil.
+func (e *Extensions) Get(profileName string) *Extension {
+	switch {
+	case e == nil:
+		return &Extension{}
+	case profileName == scw.DefaultProfileName:
+		return &e.Default
+	case e.Profiles[profileName] == nil:
+		return &Extension{}
+	}
+	return e.Profiles[profileName]
+}
+
+// IsEmpty returns true if no profile uses extensions.
+func (e *Extensions) IsEmpty() bool {
+	return e == nil || (e.Default == (Extension{}) && len(e.Profiles) == 0)
+}
+
+// ExtendedBy returns the profiles extending the given profile, sorted by name.
+func (e *Extensions) ExtendedBy(profileName string) []string {
+	children := []string(nil)
+	if e == nil {
+		return children
+	}
+	for name, extension := range e.Profiles {
+		if extension != nil && extension.Extends == profileName {
+			children = append(children, name)
+		}
+	}
+	sort.Strings(children)
+	return children
+}
+
+// LoadConfig loads the config file and the extensions of its profiles.
+func LoadConfig(path string) (*scw.Config, *Extensions, error) {
+	config, err := scw.LoadConfigFromPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	extensions, err := loadExtensions(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return config, extensions, nil
+}
+
+func loadExtensions(path string) (*Extensions, error) {
+	extensions := &Extensions{}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return extensions, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = yaml.Unmarshal(content, extensions)
+	if err != nil {
+		return nil, fmt.Errorf("content of config file %s is invalid: %w", path, err)
+	}
+	// The default profile is inherited last, it cannot extend another profile.
+	extensions.Default.Extends = ""
+	// Only profiles using extensions are kept.
+	for name, extension := range extensions.Profiles {
+		if extension == nil || *extension == (Extension{}) {
+			delete(extensions.Profiles, name)
+		}
+	}
+	return extensions, nil
+}
+
+// SaveConfig saves the config file like scw.Config.SaveTo, keeping the extensions of the profiles that still exist.
+func SaveConfig(config *scw.Config, path string) error {
+	extensions, err := loadExtensions(path)
+	if err != nil {
+		return err
+	}
+
+	err = config.SaveTo(path)
+	if err != nil || extensions.IsEmpty() {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	profileExtensions := map[string]*Extension{scw.DefaultProfileName: &extensions.Default}
+	for name := range config.Profiles {
+		profileExtensions[name] = extensions.Get(name)
+	}
+	content, err = SetExtensions(content, profileExtensions, nil)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, info.Mode())
+}
+
+// SetExtensions sets the keys of the extensions of profiles in the YAML of a config, keeping its other keys and comments.
+// The keys are set at the beginning of named profiles. The keys of the default profile are set after its secret_key key,
+// commented or not, or at the beginning of the YAML.
+// The comments of a profile are added after the keys of its extension.
+func SetExtensions(content []byte, extensions map[string]*Extension, comments map[string][]string) ([]byte, error) {
+	document := &yaml.Node{}
+	err := yaml.Unmarshal(content, document)
+	if err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return content, nil
+	}
+	root := document.Content[0]
+
+	for name, extension := range extensions {
+		mapping := root
+		if name != scw.DefaultProfileName {
+			mapping = mappingValue(mappingValue(root, "profiles"), name)
+		}
+		if mapping != nil && mapping.Kind == yaml.ScalarNode && mapping.Tag == "!!null" {
+			// Profiles without values are saved without a mapping.
+			mapping.Kind, mapping.Tag, mapping.Value = yaml.MappingNode, "!!map", ""
+		}
+		if mapping == nil || mapping.Kind != yaml.MappingNode {
+			continue
+		}
+		err = setExtension(mapping, extension, comments[name], name == scw.DefaultProfileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	err = encoder.Encode(document)
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), encoder.Close()
+}
+
+// setExtension sets the keys of an extension in the mapping of a profile.
+func setExtension(mapping *yaml.Node, extension *Extension, comments []string, isDefault bool) error {
+	keys := &yaml.Node{}
+	err := keys.Encode(extension)
+	if err != nil {
+		return err
+	}
+
+	// Keys already in the mapping are updated, the other ones are inserted.
+	inserted := []*yaml.Node(nil)
+	for i := 0; i < len(keys.Content); i += 2 {
+		if value := mappingValue(mapping, keys.Content[i].Value); value != nil {
+			*value = *keys.Content[i+1]
+			continue
+		}
+		inserted = append(inserted, keys.Content[i], keys.Content[i+1])
+	}
+
+	position := 0
+	if isDefault {
+		position = secretKeyPosition(mapping, inserted)
+	}
+	if len(inserted) > 0 {
+		// Empty profiles are saved as {}, they are written as blocks once they have keys.
+		mapping.Style = 0
+		mapping.Content = append(mapping.Content[:position], append(inserted, mapping.Content[position:]...)...)
+	}
+
+	if len(comments) > 0 {
+		comment := "# " + strings.Join(comments, "\n# ")
+		position += len(inserted)
+		switch {
+		case position < len(mapping.Content):
+			mapping.Content[position].HeadComment = strings.TrimSuffix(comment+"\n"+mapping.Content[position].HeadComment, "\n")
+		case position > 0:
+			mapping.Content[position-1].LineComment = comment
+		}
+	}
+	return nil
+}
+
+// secretKeyPosition returns the position of the keys inserted after the secret_key key of a mapping.
+// When the key is commented, the comment and the ones before it are moved to the first inserted key.
+func secretKeyPosition(mapping *yaml.Node, inserted []*yaml.Node) int {
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == "secret_key" {
+			return i + 2
+		}
+		before, after, found := strings.Cut(mapping.Content[i].HeadComment, "# secret_key:")
+		if !found || len(inserted) == 0 {
+			continue
+		}
+		line, after, _ := strings.Cut(after, "\n")
+		inserted[0].HeadComment = before + "# secret_key:" + line
+		mapping.Content[i].HeadComment = after
+		return i
+	}
+	return 0
+}
+
+// mappingValue returns the value of a key of a mapping node, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// Resolve returns a profile with the values it inherits from the profiles it extends and from the default profile.
+// The values of a profile have priority over the ones of the profile it extends, the default profile comes last.
+// The secret key is not set when it is the output of a secret_key_command, see SecretKeyCommand.
+func Resolve(config *scw.Config, extensions *Extensions, profileName string) (*scw.Profile, Origins, error) {
+	chain, err := Chain(config, extensions, profileName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	profile := &scw.Profile{}
+	origins := Origins{}
+	// The chain starts with the given profile, its values are applied last.
+	for i := len(chain) - 1; i >= 0; i-- {
+		source := &config.Profile
+		if chain[i] != scw.DefaultProfileName {
+			source = config.Profiles[chain[i]]
+		}
+		for key := range setValues(source) {
+			origins[key] = chain[i]
+		}
+		profile = scw.MergeProfiles(profile, source)
+	}
+
+	// A secret_key_command has priority over the secret keys of the profiles it is inherited from.
+	extension, _, err := SecretKeyCommand(config, extensions, profileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if extension != nil {
+		profile.SecretKey = nil
+		delete(origins, "secret_key")
+	}
+
+	return profile, origins, nil
+}
+
+// Chain returns the profiles a profile inherits from, starting with the profile itself and ending with the default profile.
+func Chain(config *scw.Config, extensions *Extensions, profileName string) ([]string, error) {
+	if profileName == "" {
+		return nil, errors.New("profileName cannot be empty")
+	}
+
+	chain := []string(nil)
+	name := profileName
+	for name != scw.DefaultProfileName {
+		if _, exists := config.Profiles[name]; !exists {
+			if name == profileName {
+				// The SDK returns the error of unknown profiles.
+				_, err := config.GetProfile(name)
+				return nil, err
+			}
+			return nil, fmt.Errorf("profile %s extends unknown profile %s", chain[len(chain)-1], name)
+		}
+		for _, previous := range chain {
+			if previous == name {
+				return nil, fmt.Errorf("profile %s cannot extend itself: %s", profileName, strings.Join(append(chain, name), " -> "))
+			}
+		}
+		chain = append(chain, name)
+
+		name = extensions.Get(name).Extends
+		if name == "" {
+			name = scw.DefaultProfileName
+		}
+	}
+
+	return append(chain, scw.DefaultProfileName), nil
+}
+
+// SecretKeyCommand returns the extension setting the secret_key_command of a profile and the name of the profile it comes from.
+// Like other values, the command is inherited from the profiles the profile extends and from the default profile.
+// A nil extension is returned if the profile or a profile it extends first sets a secret_key, or if no profile sets a command.
+func SecretKeyCommand(config *scw.Config, extensions *Extensions, profileName string) (*Extension, string, error) {
+	chain, err := Chain(config, extensions, profileName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, name := range chain {
+		source := &config.Profile
+		if name != scw.DefaultProfileName {
+			source = config.Profiles[name]
+		}
+		if source.SecretKey != nil {
+			return nil, "", nil
+		}
+		if extension := extensions.Get(name); extension.SecretKeyCommand != "" {
+			return extension, name, nil
+		}
+	}
+
+	return nil, "", nil
+}
+
+// setValues returns the YAML keys of the values set in a profile.
+func setValues(profile *scw.Profile) map[string]bool {
+	keys := map[string]bool{}
+	value := reflect.ValueOf(profile).Elem()
+	for i := range value.NumField() {
+		if value.Field(i).IsNil() {
+			continue
+		}
+		key, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("yaml"), ",")
+		keys[key] = true
+	}
+	return keys
+}
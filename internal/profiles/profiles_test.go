@@ -0,0 +1,178 @@
+package profiles_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/profiles"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `access_key: SCWXXXXXXXXXXXXXXXXX
+default_region: fr-par
+profiles:
+  base:
+    access_key: SCWBASEXXXXXXXXXXXXX
+    default_zone: fr-par-2
+  staging:
+    extends: base
+    default_zone: nl-ams-1
+  prod:
+    extends: staging
+    default_project_id: 11111111-1111-1111-1111-111111111111
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestResolve(t *testing.T) {
+	config, extensions, err := profiles.LoadConfig(writeConfig(t, testConfig))
+	require.NoError(t, err)
+
+	profile, origins, err := profiles.Resolve(config, extensions, "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "SCWBASEXXXXXXXXXXXXX", *profile.AccessKey)
+	assert.Equal(t, "nl-ams-1", *profile.DefaultZone)
+	assert.Equal(t, "fr-par", *profile.DefaultRegion)
+	assert.Equal(t, profiles.Origins{
+		"access_key":         "base",
+		"default_zone":       "staging",
+		"default_region":     "default",
+		"default_project_id": "prod",
+	}, origins)
+
+	_, origins, err = profiles.Resolve(config, extensions, scw.DefaultProfileName)
+	require.NoError(t, err)
+	assert.Equal(t, profiles.Origins{"access_key": "default", "default_region": "default"}, origins)
+}
+
+func TestChain(t *testing.T) {
+	config, extensions, err := profiles.LoadConfig(writeConfig(t, testConfig))
+	require.NoError(t, err)
+
+	chain, err := profiles.Chain(config, extensions, "prod")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod", "staging", "base", "default"}, chain)
+
+	_, err = profiles.Chain(config, extensions, "unknown")
+	assert.EqualError(t, err, "scaleway-sdk-go: given profile unknown does not exist")
+
+	extensions.Profiles["base"] = &profiles.Extension{Extends: "prod"}
+	_, err = profiles.Chain(config, extensions, "prod")
+	assert.EqualError(t, err, "profile prod cannot extend itself: prod -> staging -> base -> prod")
+
+	extensions.Profiles["base"] = &profiles.Extension{Extends: "qa"}
+	_, err = profiles.Chain(config, extensions, "prod")
+	assert.EqualError(t, err, "profile base extends unknown profile qa")
+}
+
+func TestSaveConfig(t *testing.T) {
+	path := writeConfig(t, testConfig)
+	config, _, err := profiles.LoadConfig(path)
+	require.NoError(t, err)
+
+	config.Profiles["prod"].DefaultRegion = scw.StringPtr("nl-ams")
+	delete(config.Profiles, "staging")
+	require.NoError(t, profiles.SaveConfig(config, path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "  prod:\n    extends: staging\n")
+	assert.NotContains(t, string(content), "staging:")
+
+	config, extensions, err := profiles.LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "nl-ams", *config.Profiles["prod"].DefaultRegion)
+	assert.Equal(t, "staging", extensions.Get("prod").Extends)
+	assert.Empty(t, extensions.Get("base").Extends)
+}
+
+func TestSecretKeyCommand(t *testing.T) {
+	config, extensions, err := profiles.LoadConfig(writeConfig(t, `secret_key_command: pass show scw/default
+profiles:
+  base:
+    secret_key_command: pass show scw/base
+    secret_key_cache: process
+  prod:
+    extends: base
+  static:
+    extends: base
+    secret_key: 22222222-2222-2222-2222-222222222222
+  other: {}
+`))
+	require.NoError(t, err)
+
+	extension, origin, err := profiles.SecretKeyCommand(config, extensions, "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "base", origin)
+	assert.Equal(t, &profiles.Extension{SecretKeyCommand: "pass show scw/base", SecretKeyCache: profiles.SecretKeyCacheProcess}, extension)
+
+	extension, origin, err = profiles.SecretKeyCommand(config, extensions, "other")
+	require.NoError(t, err)
+	assert.Equal(t, scw.DefaultProfileName, origin)
+	assert.Equal(t, "pass show scw/default", extension.SecretKeyCommand)
+
+	extension, _, err = profiles.SecretKeyCommand(config, extensions, "static")
+	require.NoError(t, err)
+	assert.Nil(t, extension)
+
+	profile, origins, err := profiles.Resolve(config, extensions, "static")
+	require.NoError(t, err)
+	assert.Equal(t, "22222222-2222-2222-2222-222222222222", *profile.SecretKey)
+	assert.Equal(t, "static", origins["secret_key"])
+}
+
+func TestSaveConfigSecretKeyCommand(t *testing.T) {
+	path := writeConfig(t, `access_key: SCWXXXXXXXXXXXXXXXXX
+secret_key_command: pass show scw/default
+profiles:
+  prod:
+    secret_key_command: 'pass show "scw/prod: main"'
+    secret_key_cache: process
+`)
+	config, _, err := profiles.LoadConfig(path)
+	require.NoError(t, err)
+	require.NoError(t, profiles.SaveConfig(config, path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# secret_key: 11111111-1111-1111-1111-111111111111\nsecret_key_command: pass show scw/default\n")
+
+	_, extensions, err := profiles.LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "pass show scw/default", extensions.Get(scw.DefaultProfileName).SecretKeyCommand)
+	assert.Equal(t, &profiles.Extension{SecretKeyCommand: `pass show "scw/prod: main"`, SecretKeyCache: profiles.SecretKeyCacheProcess}, extensions.Get("prod"))
+}
+
+func TestSaveConfigKeepsExtensions(t *testing.T) {
+	path := writeConfig(t, `secret_key: 11111111-1111-1111-1111-111111111111
+secret_key_command: pass show scw/default
+secret_key_cache: process
+profiles:
+  base:
+    secret_key_command: pass show scw/base
+    secret_key_cache: process
+  prod:
+    extends: base
+    secret_key_command: pass show scw/prod
+    secret_key_cache: process
+    default_zone: nl-ams-1
+  empty:
+    extends: prod
+`)
+	config, extensions, err := profiles.LoadConfig(path)
+	require.NoError(t, err)
+	require.NoError(t, profiles.SaveConfig(config, path))
+
+	saved, savedExtensions, err := profiles.LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, extensions, savedExtensions)
+	assert.Equal(t, config, saved)
+}
@@ -0,0 +1,42 @@
+// Package query evaluates JMESPath (https://jmespath.org) expressions to
+// extract values from a command result.
+package query
+
+import (
+	"encoding/json"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+// Query is a compiled query expression.
+type Query struct {
+	expression *jmespath.JMESPath
+}
+
+// Compile parses a query expression.
+func Compile(expression string) (*Query, error) {
+	compiled, err := jmespath.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &Query{
+		expression: compiled,
+	}, nil
+}
+
+// Search evaluates the query against data.
+// data is first converted to its JSON representation so that fields are accessed using their JSON names.
+func (q *Query) Search(data interface{}) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	value := interface{}(nil)
+	err = json.Unmarshal(raw, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.expression.Search(value)
+}